@@ -7,26 +7,40 @@ SPDX-License-Identifier: Apache-2.0
 package main
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/np-guard/cloud-resource-collector/pkg/common"
 	"github.com/np-guard/cloud-resource-collector/pkg/factory"
+	"github.com/np-guard/cloud-resource-collector/pkg/tfstate"
 )
 
 func collectResources(cmd *cobra.Command, _ []string) error {
 	cmd.SilenceUsage = true // if we got this far, flags are syntactically correct, so no need to print usage
 
-	if provider != common.IBM {
+	info, err := common.GetProvider(provider)
+	if err != nil {
+		return err
+	}
+	if !info.SupportsResourceGroups {
 		if resourceGroupID != "" {
 			return fmt.Errorf("setting resource-group from the command-line for provider %s is not yet supported. ", provider)
 		}
 	}
+	err = checkOtherProvidersFlags()
+	if err != nil {
+		return err
+	}
 
-	resources := factory.GetResourceContainer(provider, regions, resourceGroupID)
-	// Collect resources from the provider API and generate output
-	err := resources.CollectResourcesFromAPI()
+	var resources common.ResourcesContainerInf
+	if tfStateFile != "" {
+		resources, err = resourcesFromTerraformState(info)
+	} else {
+		resources, err = resourcesFromAPI()
+	}
 	if err != nil {
 		return err
 	}
@@ -34,3 +48,47 @@ func collectResources(cmd *cobra.Command, _ []string) error {
 	resources.PrintStats()
 	return nil
 }
+
+// resourcesFromAPI collects resources from the provider API
+func resourcesFromAPI() (common.ResourcesContainerInf, error) {
+	resources, err := factory.GetResourceContainer(provider, regions, resourceGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return resources, resources.CollectResourcesFromAPI()
+}
+
+// resourcesFromTerraformState translates the resources in a Terraform state, without calling the provider API
+func resourcesFromTerraformState(info *common.ProviderInfo) (common.ResourcesContainerInf, error) {
+	if info.FromTerraformState == nil {
+		return nil, fmt.Errorf("reading resources from Terraform state for provider %s is not yet supported", provider)
+	}
+	if len(regions) > 0 || resourceGroupID != "" {
+		return nil, errors.New("the region and resource-group flags cannot be used with from-tfstate")
+	}
+	state, err := tfstate.Load(tfStateFile)
+	if err != nil {
+		return nil, err
+	}
+	return info.FromTerraformState(state)
+}
+
+// checkOtherProvidersFlags returns an error if a flag specific to a provider other than the selected one is set
+func checkOtherProvidersFlags() error {
+	var err error
+	for _, name := range common.AllProviders() {
+		if common.Provider(name) == provider {
+			continue
+		}
+		info, _ := common.GetProvider(common.Provider(name))
+		if info.Flags == nil {
+			continue
+		}
+		info.Flags.VisitAll(func(flag *pflag.Flag) {
+			if flag.Changed && err == nil {
+				err = fmt.Errorf("the %s flag is not supported for provider %s", flag.Name, provider)
+			}
+		})
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/common"
+)
+
+const (
+	outDirPermission = 0o755
+	tfFilePermission = 0o600
+	defaultTFOutDir  = "terraform"
+)
+
+func newExportTerraformCommand() *cobra.Command {
+	var snapshot []byte
+	var outDir string
+	exportCmd := &cobra.Command{
+		Use:   "export-terraform <snapshot.json>",
+		Short: "Export collected resources as Terraform configuration",
+		Long: `Generate Terraform configuration files recreating the VPC resources in a snapshot produced by the collect
+or fabricate commands. The provider is taken from the snapshot, unless set with --provider`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			snapshot, err = os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed(providerFlag) {
+				return nil
+			}
+			metadata := common.ResourceModelMetadata{}
+			if err = json.Unmarshal(snapshot, &metadata); err != nil {
+				return fmt.Errorf("error parsing snapshot %s: %w", args[0], err)
+			}
+			if metadata.Provider == "" {
+				return nil // the provider flag is required, so the user is asked to set it
+			}
+			return cmd.Flags().Set(providerFlag, metadata.Provider)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true
+			return exportTerraform(snapshot, outDir)
+		},
+	}
+	exportCmd.Flags().StringVar(&outDir, "out-dir", defaultTFOutDir, "directory in which to write the Terraform files")
+
+	return exportCmd
+}
+
+// exportTerraform writes a Terraform configuration recreating the resources in a snapshot to the given directory
+func exportTerraform(snapshot []byte, outDir string) error {
+	info, err := common.GetProvider(provider)
+	if err != nil {
+		return err
+	}
+	if info.ExportTerraform == nil {
+		return fmt.Errorf("exporting Terraform configuration for provider %s is not yet supported", provider)
+	}
+	config, err := info.ExportTerraform(snapshot)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(outDir, outDirPermission); err != nil {
+		return err
+	}
+	files := config.Files()
+	for _, name := range config.FileNames() {
+		path := filepath.Join(outDir, name)
+		log.Printf("Writing to file: %s", path)
+		if err = os.WriteFile(path, files[name], tfFilePermission); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,11 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package main
 
 import (
+	"fmt"
 	"os"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/factory"
 )
 
 func main() {
-	err := newRootCommand().Execute()
+	err := factory.RegisterBuiltinProviders()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	err = newRootCommand().Execute()
 	if err != nil {
 		os.Exit(1) // error was already printed by Cobra
 	}
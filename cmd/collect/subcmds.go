@@ -26,6 +26,7 @@ var (
 	regions         []string
 	resourceGroupID string
 	outputFile      string
+	tfStateFile     string
 
 	fabricatesOpts common.FabricateOptions
 )
@@ -38,7 +39,7 @@ func newRootCommand() *cobra.Command {
 		Version: version.VersionCore,
 	}
 
-	providerHelp := fmt.Sprintf("collect resources from an account in this cloud provider. Supported providers: %s", common.AllProvidersStr)
+	providerHelp := fmt.Sprintf("collect resources from an account in this cloud provider. Supported providers: %s", common.AllProvidersStr())
 	rootCmd.PersistentFlags().VarP(&provider, providerFlag, "p", providerHelp)
 	_ = rootCmd.MarkPersistentFlagRequired(providerFlag)
 
@@ -47,6 +48,7 @@ func newRootCommand() *cobra.Command {
 	rootCmd.AddCommand(newCollectCommand())
 	rootCmd.AddCommand(newGetRegionsCommand())
 	rootCmd.AddCommand(newFabricateCommand())
+	rootCmd.AddCommand(newExportTerraformCommand())
 
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true}) // disable help command. should use --help flag instead
 
@@ -64,6 +66,13 @@ func newCollectCommand() *cobra.Command {
 
 	collectCmd.Flags().StringArrayVarP(&regions, "region", "r", nil, "cloud region from which to collect resources")
 	collectCmd.Flags().StringVar(&resourceGroupID, "resource-group", "", "resource group id or name from which to collect resources")
+	collectCmd.Flags().StringVar(&tfStateFile, "from-tfstate", "",
+		"translate the resources in a Terraform state file (or \"terraform show -json\" output) instead of calling the cloud API")
+	for _, name := range common.AllProviders() {
+		if info, err := common.GetProvider(common.Provider(name)); err == nil && info.Flags != nil {
+			collectCmd.Flags().AddFlagSet(info.Flags)
+		}
+	}
 
 	return collectCmd
 }
@@ -75,8 +84,11 @@ func newGetRegionsCommand() *cobra.Command {
 		Long:  `List all regions that can be used with the --region flag`,
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			resources := factory.GetResourceContainer(provider, nil, "")
-			providerRegions := strings.Join(resources.AllRegions(), ", ")
+			info, err := common.GetProvider(provider)
+			if err != nil {
+				return err
+			}
+			providerRegions := strings.Join(info.AllRegions(), ", ")
 			fmt.Printf("Available regions for provider %s: %s\n", provider, providerRegions)
 			return nil
 		},
@@ -90,7 +102,17 @@ func newFabricateCommand() *cobra.Command {
 		Long:  `Generates synthetic data with a given number of VPCs, Subnets, VSIs, ...`,
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			resources := factory.GetResourceContainer(provider, regions, "")
+			info, err := common.GetProvider(provider)
+			if err != nil {
+				return err
+			}
+			if !info.SupportsFabricate {
+				return fmt.Errorf("fabricating synthetic data for provider %s is not yet supported", provider)
+			}
+			resources, err := factory.GetResourceContainer(provider, regions, "")
+			if err != nil {
+				return err
+			}
 			resources.Fabricate(&fabricatesOpts)
 			OutputResources(resources, outputFile)
 			return nil
@@ -0,0 +1,119 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// getCallerIdentity returns the identity (account and ARN) whose credentials are used for collecting resources
+func getCallerIdentity(cfg *awssdk.Config) (*sts.GetCallerIdentityOutput, error) {
+	identity, err := sts.NewFromConfig(*cfg).GetCallerIdentity(context.TODO(), &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("CollectResourcesFromAPI error getting the caller identity: %w", err)
+	}
+	return identity, nil
+}
+
+// assumeRoleCredentials returns (cached) credentials of the given role, assumed using the credentials in cfg
+func assumeRoleCredentials(cfg *awssdk.Config, roleArn string) awssdk.CredentialsProvider {
+	return awssdk.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(*cfg), roleArn))
+}
+
+// collectOrganizationResources collects resources from all active accounts in the organization, by assuming the role
+// named OrgRoleName in each of them. A failure to collect from one account does not abort collecting from the others
+func (resources *ResourcesContainer) collectOrganizationResources(cfg *awssdk.Config) error {
+	identity, err := getCallerIdentity(cfg)
+	if err != nil {
+		return err
+	}
+	callerArn, err := arn.Parse(*identity.Arn)
+	if err != nil {
+		return fmt.Errorf("CollectResourcesFromAPI error parsing the caller ARN %s: %w", *identity.Arn, err)
+	}
+
+	accounts, err := getOrganizationAccounts(cfg)
+	if err != nil {
+		return err
+	}
+
+	collectedAccounts := 0
+	failedAccounts := []string{}
+	for i := range accounts {
+		accountID := *accounts[i].Id
+		if accounts[i].Status != orgtypes.AccountStatusActive {
+			log.Printf("Skipping account %s, whose status is %s\n", accountID, accounts[i].Status)
+			continue
+		}
+
+		log.Printf("Collecting resources from account %s\n", accountID)
+		collectedAccounts++
+		roleArn := arn.ARN{
+			Partition: callerArn.Partition,
+			Service:   "iam",
+			AccountID: accountID,
+			Resource:  "role/" + resources.options.OrgRoleName,
+		}
+		accountCfg := cfg.Copy()
+		accountCfg.Credentials = assumeRoleCredentials(cfg, roleArn.String())
+
+		// collect into a separate container, so that a failing account does not leave partial results
+		accountResources := NewResourcesContainer(resources.regions, &resources.options)
+		err := accountResources.collectAccountResources(&accountCfg, accountID)
+		if err != nil {
+			log.Printf("Failed collecting resources from account %s: %v\n", accountID, err)
+			failedAccounts = append(failedAccounts, accountID)
+			continue
+		}
+		resources.appendResources(accountResources)
+	}
+
+	if len(failedAccounts) > 0 {
+		log.Printf("Could not collect resources from %d accounts: %s\n", len(failedAccounts), strings.Join(failedAccounts, ", "))
+		if len(failedAccounts) == collectedAccounts {
+			return fmt.Errorf("CollectResourcesFromAPI failed collecting resources from all %d accounts", collectedAccounts)
+		}
+	}
+	return nil
+}
+
+// getOrganizationAccounts lists all accounts in the organization. This requires the credentials of
+// the organization's management account or of a delegated administrator
+func getOrganizationAccounts(cfg *awssdk.Config) ([]orgtypes.Account, error) {
+	res := []orgtypes.Account{}
+	paginator := organizations.NewListAccountsPaginator(organizations.NewFromConfig(*cfg), &organizations.ListAccountsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getOrganizationAccounts] error listing organization accounts: %w", err)
+		}
+		res = append(res, page.Accounts...)
+	}
+	return res, nil
+}
+
+// appendResources appends all resources in other to the resources in this container
+func (resources *ResourcesContainer) appendResources(other *ResourcesContainer) {
+	dst := reflect.ValueOf(resources).Elem()
+	src := reflect.ValueOf(other).Elem()
+	for i := range dst.NumField() {
+		if dst.Type().Field(i).IsExported() && dst.Field(i).Kind() == reflect.Slice {
+			dst.Field(i).Set(reflect.AppendSlice(dst.Field(i), src.Field(i)))
+		}
+	}
+}
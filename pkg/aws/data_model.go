@@ -0,0 +1,63 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aws
+
+import (
+	aws2 "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// The following types define the data model for AWS resources.
+// Each is the SDK type, extended with the account and region in which the resource resides,
+// and with extra information from other API calls where needed
+
+// ResourceLocation identifies the account and the region in which a resource resides
+type ResourceLocation struct {
+	AccountID string
+	Region    string
+}
+
+// VPC configuration object
+type VPC struct {
+	aws2.Vpc
+	ResourceLocation
+}
+
+// Subnet configuration object
+type Subnet struct {
+	aws2.Subnet
+	ResourceLocation
+}
+
+// InternetGateway configuration object
+type InternetGateway struct {
+	aws2.InternetGateway
+	ResourceLocation
+}
+
+// NetworkACL configuration object
+type NetworkACL struct {
+	aws2.NetworkAcl
+	ResourceLocation
+}
+
+// SecurityGroup configuration object
+type SecurityGroup struct {
+	aws2.SecurityGroup
+	ResourceLocation
+}
+
+// SecurityGroupRule is a single rule of a security group, linked to the group by its GroupId
+type SecurityGroupRule struct {
+	aws2.SecurityGroupRule
+	ResourceLocation
+}
+
+// Instance configuration object
+type Instance struct {
+	aws2.Instance
+	ResourceLocation
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	aws2 "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// PrefixList is a managed prefix list, together with its entries (CIDRs).
+// Security group rules and routes reference prefix lists by their PrefixListId
+type PrefixList struct {
+	aws2.ManagedPrefixList
+	Entries []aws2.PrefixListEntry
+	ResourceLocation
+}
+
+// getPrefixLists gets all managed prefix lists, including AWS-managed lists (e.g., for S3 and DynamoDB), with their entries
+func getPrefixLists(client *ec2.Client, loc ResourceLocation) ([]*PrefixList, error) {
+	res := []*PrefixList{}
+	paginator := ec2.NewDescribeManagedPrefixListsPaginator(client, &ec2.DescribeManagedPrefixListsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getPrefixLists] error getting managed prefix lists: %w", err)
+		}
+		for i := range page.PrefixLists {
+			entries, err := getPrefixListEntries(client, page.PrefixLists[i].PrefixListId)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, &PrefixList{ManagedPrefixList: page.PrefixLists[i], Entries: entries, ResourceLocation: loc})
+		}
+	}
+	return res, nil
+}
+
+func getPrefixListEntries(client *ec2.Client, prefixListID *string) ([]aws2.PrefixListEntry, error) {
+	res := []aws2.PrefixListEntry{}
+	paginator := ec2.NewGetManagedPrefixListEntriesPaginator(client, &ec2.GetManagedPrefixListEntriesInput{PrefixListId: prefixListID})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getPrefixListEntries] error getting entries of prefix list %s: %w", *prefixListID, err)
+		}
+		res = append(res, page.Entries...)
+	}
+	return res, nil
+}
+
+// getSecurityGroupRules gets all security group rules in their flat form, each with its own rule ID and description.
+// Rules are linked to their security group by GroupId
+func getSecurityGroupRules(client *ec2.Client, loc ResourceLocation) ([]*SecurityGroupRule, error) {
+	res := []*SecurityGroupRule{}
+	paginator := ec2.NewDescribeSecurityGroupRulesPaginator(client, &ec2.DescribeSecurityGroupRulesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getSecurityGroupRules] error getting security group rules: %w", err)
+		}
+		for i := range page.SecurityGroupRules {
+			res = append(res, &SecurityGroupRule{SecurityGroupRule: page.SecurityGroupRules[i], ResourceLocation: loc})
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+)
+
+// EKSCluster is an EKS cluster, together with its node groups and Fargate profiles.
+// The cluster's subnets, security groups and endpoint access configuration are under ResourcesVpcConfig
+type EKSCluster struct {
+	ekstypes.Cluster
+	NodeGroups      []ekstypes.Nodegroup
+	FargateProfiles []ekstypes.FargateProfile
+	ResourceLocation
+}
+
+// collectEKSClusters uses the EKS API to collect all EKS clusters of a single region
+func (resources *ResourcesContainer) collectEKSClusters(cfg *awssdk.Config, loc ResourceLocation) error {
+	clusters, err := getEKSClusters(eks.NewFromConfig(*cfg), loc)
+	if err != nil {
+		return err
+	}
+	resources.EKSClustersList = append(resources.EKSClustersList, clusters...)
+	return nil
+}
+
+// getEKSClusters gets all EKS clusters, with all their node groups and Fargate profiles
+func getEKSClusters(client *eks.Client, loc ResourceLocation) ([]*EKSCluster, error) {
+	res := []*EKSCluster{}
+	paginator := eks.NewListClustersPaginator(client, &eks.ListClustersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getEKSClusters] error listing clusters: %w", err)
+		}
+		for i := range page.Clusters {
+			clusterName := &page.Clusters[i]
+			cluster, err := client.DescribeCluster(context.TODO(), &eks.DescribeClusterInput{Name: clusterName})
+			if err != nil {
+				return nil, fmt.Errorf("[getEKSClusters] error getting cluster %s: %w", *clusterName, err)
+			}
+			nodeGroups, err := getEKSNodeGroups(client, clusterName)
+			if err != nil {
+				return nil, err
+			}
+			fargateProfiles, err := getEKSFargateProfiles(client, clusterName)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, &EKSCluster{
+				Cluster:          *cluster.Cluster,
+				NodeGroups:       nodeGroups,
+				FargateProfiles:  fargateProfiles,
+				ResourceLocation: loc,
+			})
+		}
+	}
+	return res, nil
+}
+
+func getEKSNodeGroups(client *eks.Client, clusterName *string) ([]ekstypes.Nodegroup, error) {
+	res := []ekstypes.Nodegroup{}
+	paginator := eks.NewListNodegroupsPaginator(client, &eks.ListNodegroupsInput{ClusterName: clusterName})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getEKSNodeGroups] error listing node groups of %s: %w", *clusterName, err)
+		}
+		for i := range page.Nodegroups {
+			nodeGroup, err := client.DescribeNodegroup(context.TODO(),
+				&eks.DescribeNodegroupInput{ClusterName: clusterName, NodegroupName: &page.Nodegroups[i]})
+			if err != nil {
+				return nil, fmt.Errorf("[getEKSNodeGroups] error getting node group %s of %s: %w", page.Nodegroups[i], *clusterName, err)
+			}
+			res = append(res, *nodeGroup.Nodegroup)
+		}
+	}
+	return res, nil
+}
+
+func getEKSFargateProfiles(client *eks.Client, clusterName *string) ([]ekstypes.FargateProfile, error) {
+	res := []ekstypes.FargateProfile{}
+	paginator := eks.NewListFargateProfilesPaginator(client, &eks.ListFargateProfilesInput{ClusterName: clusterName})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getEKSFargateProfiles] error listing Fargate profiles of %s: %w", *clusterName, err)
+		}
+		for i := range page.FargateProfileNames {
+			profile, err := client.DescribeFargateProfile(context.TODO(),
+				&eks.DescribeFargateProfileInput{ClusterName: clusterName, FargateProfileName: &page.FargateProfileNames[i]})
+			if err != nil {
+				return nil, fmt.Errorf("[getEKSFargateProfiles] error getting Fargate profile %s of %s: %w",
+					page.FargateProfileNames[i], *clusterName, err)
+			}
+			res = append(res, *profile.FargateProfile)
+		}
+	}
+	return res, nil
+}
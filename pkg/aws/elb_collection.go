@@ -0,0 +1,163 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+// LoadBalancer is an application, network or gateway load balancer (ELBv2), together with its listeners.
+// Its subnets are listed under AvailabilityZones, and its security groups under SecurityGroups
+type LoadBalancer struct {
+	elbv2types.LoadBalancer
+	Listeners []*Listener
+	ResourceLocation
+}
+
+// Listener is an ELBv2 listener, together with its rules
+type Listener struct {
+	elbv2types.Listener
+	Rules []elbv2types.Rule
+}
+
+// TargetGroup is an ELBv2 target group, together with the health of each of its registered targets
+type TargetGroup struct {
+	elbv2types.TargetGroup
+	TargetHealth []elbv2types.TargetHealthDescription
+	ResourceLocation
+}
+
+// ClassicLoadBalancer is a classic (ELBv1) load balancer, including its subnets, security groups and instances
+type ClassicLoadBalancer struct {
+	elbtypes.LoadBalancerDescription
+	ResourceLocation
+}
+
+// collectLoadBalancers uses the elasticloadbalancing APIs to collect all load balancers of a single region
+func (resources *ResourcesContainer) collectLoadBalancers(cfg *awssdk.Config, loc ResourceLocation) error {
+	elbv2Client := elbv2.NewFromConfig(*cfg)
+
+	lbs, err := getLoadBalancers(elbv2Client, loc)
+	if err != nil {
+		return err
+	}
+	resources.LBsList = append(resources.LBsList, lbs...)
+
+	targetGroups, err := getTargetGroups(elbv2Client, loc)
+	if err != nil {
+		return err
+	}
+	resources.TargetGroupsList = append(resources.TargetGroupsList, targetGroups...)
+
+	classicLBs, err := getClassicLoadBalancers(elb.NewFromConfig(*cfg), loc)
+	if err != nil {
+		return err
+	}
+	resources.ClassicLBsList = append(resources.ClassicLBsList, classicLBs...)
+
+	return nil
+}
+
+// getLoadBalancers gets all ELBv2 load balancers, with all their listeners and all the rules of each listener
+func getLoadBalancers(client *elbv2.Client, loc ResourceLocation) ([]*LoadBalancer, error) {
+	res := []*LoadBalancer{}
+	paginator := elbv2.NewDescribeLoadBalancersPaginator(client, &elbv2.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getLoadBalancers] error getting load balancers: %w", err)
+		}
+		for i := range page.LoadBalancers {
+			listeners, err := getListeners(client, page.LoadBalancers[i].LoadBalancerArn)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, &LoadBalancer{LoadBalancer: page.LoadBalancers[i], Listeners: listeners, ResourceLocation: loc})
+		}
+	}
+	return res, nil
+}
+
+func getListeners(client *elbv2.Client, lbArn *string) ([]*Listener, error) {
+	res := []*Listener{}
+	paginator := elbv2.NewDescribeListenersPaginator(client, &elbv2.DescribeListenersInput{LoadBalancerArn: lbArn})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getListeners] error getting listeners for %s: %w", *lbArn, err)
+		}
+		for i := range page.Listeners {
+			rules, err := getListenerRules(client, page.Listeners[i].ListenerArn)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, &Listener{Listener: page.Listeners[i], Rules: rules})
+		}
+	}
+	return res, nil
+}
+
+func getListenerRules(client *elbv2.Client, listenerArn *string) ([]elbv2types.Rule, error) {
+	res := []elbv2types.Rule{}
+	paginator := elbv2.NewDescribeRulesPaginator(client, &elbv2.DescribeRulesInput{ListenerArn: listenerArn})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getListenerRules] error getting rules for %s: %w", *listenerArn, err)
+		}
+		res = append(res, page.Rules...)
+	}
+	return res, nil
+}
+
+// getTargetGroups gets all ELBv2 target groups, with the health of their registered targets
+func getTargetGroups(client *elbv2.Client, loc ResourceLocation) ([]*TargetGroup, error) {
+	res := []*TargetGroup{}
+	paginator := elbv2.NewDescribeTargetGroupsPaginator(client, &elbv2.DescribeTargetGroupsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getTargetGroups] error getting target groups: %w", err)
+		}
+		for i := range page.TargetGroups {
+			tgArn := page.TargetGroups[i].TargetGroupArn
+			health, err := client.DescribeTargetHealth(context.TODO(), &elbv2.DescribeTargetHealthInput{TargetGroupArn: tgArn})
+			if err != nil {
+				return nil, fmt.Errorf("[getTargetGroups] error getting target health for %s: %w", *tgArn, err)
+			}
+			res = append(res, &TargetGroup{
+				TargetGroup:      page.TargetGroups[i],
+				TargetHealth:     health.TargetHealthDescriptions,
+				ResourceLocation: loc,
+			})
+		}
+	}
+	return res, nil
+}
+
+// getClassicLoadBalancers gets all classic (ELBv1) load balancers
+func getClassicLoadBalancers(client *elb.Client, loc ResourceLocation) ([]*ClassicLoadBalancer, error) {
+	res := []*ClassicLoadBalancer{}
+	paginator := elb.NewDescribeLoadBalancersPaginator(client, &elb.DescribeLoadBalancersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getClassicLoadBalancers] error getting classic load balancers: %w", err)
+		}
+		for i := range page.LoadBalancerDescriptions {
+			res = append(res, &ClassicLoadBalancer{LoadBalancerDescription: page.LoadBalancerDescriptions[i], ResourceLocation: loc})
+		}
+	}
+	return res, nil
+}
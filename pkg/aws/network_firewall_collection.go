@@ -0,0 +1,158 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	nf "github.com/aws/aws-sdk-go-v2/service/networkfirewall"
+	nftypes "github.com/aws/aws-sdk-go-v2/service/networkfirewall/types"
+)
+
+// NetworkFirewall is an AWS Network Firewall, together with its endpoints (one per subnet in SubnetMappings)
+type NetworkFirewall struct {
+	nftypes.Firewall
+	Endpoints []FirewallEndpoint
+	ResourceLocation
+}
+
+// FirewallEndpoint is the endpoint of a Network Firewall in a single subnet. Routes send traffic to it by EndpointId
+type FirewallEndpoint struct {
+	AvailabilityZone string
+	nftypes.Attachment
+}
+
+// FirewallPolicy is a Network Firewall policy, referencing stateless and stateful rule groups
+type FirewallPolicy struct {
+	nftypes.FirewallPolicyResponse
+	FirewallPolicy *nftypes.FirewallPolicy
+	ResourceLocation
+}
+
+// FirewallRuleGroup is a stateless or stateful (see Type) Network Firewall rule group, together with its rules
+type FirewallRuleGroup struct {
+	nftypes.RuleGroupResponse
+	RuleGroup *nftypes.RuleGroup
+	ResourceLocation
+}
+
+// collectNetworkFirewalls uses the Network Firewall API to collect firewalls, policies and rule groups of a single region
+func (resources *ResourcesContainer) collectNetworkFirewalls(cfg *awssdk.Config, loc ResourceLocation) error {
+	client := nf.NewFromConfig(*cfg)
+
+	firewalls, err := getNetworkFirewalls(client, loc)
+	if err != nil {
+		return err
+	}
+	resources.NetworkFirewallsList = append(resources.NetworkFirewallsList, firewalls...)
+
+	policies, err := getFirewallPolicies(client, loc)
+	if err != nil {
+		return err
+	}
+	resources.FirewallPoliciesList = append(resources.FirewallPoliciesList, policies...)
+
+	ruleGroups, err := getFirewallRuleGroups(client, loc)
+	if err != nil {
+		return err
+	}
+	resources.FirewallRuleGroupsList = append(resources.FirewallRuleGroupsList, ruleGroups...)
+
+	return nil
+}
+
+func getNetworkFirewalls(client *nf.Client, loc ResourceLocation) ([]*NetworkFirewall, error) {
+	res := []*NetworkFirewall{}
+	paginator := nf.NewListFirewallsPaginator(client, &nf.ListFirewallsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getNetworkFirewalls] error listing firewalls: %w", err)
+		}
+		for i := range page.Firewalls {
+			firewallArn := page.Firewalls[i].FirewallArn
+			firewall, err := client.DescribeFirewall(context.TODO(), &nf.DescribeFirewallInput{FirewallArn: firewallArn})
+			if err != nil {
+				return nil, fmt.Errorf("[getNetworkFirewalls] error getting firewall %s: %w", *firewallArn, err)
+			}
+			res = append(res, &NetworkFirewall{
+				Firewall:         *firewall.Firewall,
+				Endpoints:        getFirewallEndpoints(firewall.FirewallStatus),
+				ResourceLocation: loc,
+			})
+		}
+	}
+	return res, nil
+}
+
+// getFirewallEndpoints extracts the firewall endpoints from the per-zone sync states of a firewall
+func getFirewallEndpoints(status *nftypes.FirewallStatus) []FirewallEndpoint {
+	res := []FirewallEndpoint{}
+	if status == nil {
+		return res
+	}
+	for zone, syncState := range status.SyncStates {
+		if syncState.Attachment != nil {
+			res = append(res, FirewallEndpoint{AvailabilityZone: zone, Attachment: *syncState.Attachment})
+		}
+	}
+	// sort for a deterministic output
+	slices.SortFunc(res, func(a, b FirewallEndpoint) int { return strings.Compare(a.AvailabilityZone, b.AvailabilityZone) })
+	return res
+}
+
+func getFirewallPolicies(client *nf.Client, loc ResourceLocation) ([]*FirewallPolicy, error) {
+	res := []*FirewallPolicy{}
+	paginator := nf.NewListFirewallPoliciesPaginator(client, &nf.ListFirewallPoliciesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getFirewallPolicies] error listing firewall policies: %w", err)
+		}
+		for i := range page.FirewallPolicies {
+			policyArn := page.FirewallPolicies[i].Arn
+			policy, err := client.DescribeFirewallPolicy(context.TODO(), &nf.DescribeFirewallPolicyInput{FirewallPolicyArn: policyArn})
+			if err != nil {
+				return nil, fmt.Errorf("[getFirewallPolicies] error getting firewall policy %s: %w", *policyArn, err)
+			}
+			res = append(res, &FirewallPolicy{
+				FirewallPolicyResponse: *policy.FirewallPolicyResponse,
+				FirewallPolicy:         policy.FirewallPolicy,
+				ResourceLocation:       loc,
+			})
+		}
+	}
+	return res, nil
+}
+
+func getFirewallRuleGroups(client *nf.Client, loc ResourceLocation) ([]*FirewallRuleGroup, error) {
+	res := []*FirewallRuleGroup{}
+	paginator := nf.NewListRuleGroupsPaginator(client, &nf.ListRuleGroupsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getFirewallRuleGroups] error listing rule groups: %w", err)
+		}
+		for i := range page.RuleGroups {
+			ruleGroupArn := page.RuleGroups[i].Arn
+			ruleGroup, err := client.DescribeRuleGroup(context.TODO(), &nf.DescribeRuleGroupInput{RuleGroupArn: ruleGroupArn})
+			if err != nil {
+				return nil, fmt.Errorf("[getFirewallRuleGroups] error getting rule group %s: %w", *ruleGroupArn, err)
+			}
+			res = append(res, &FirewallRuleGroup{
+				RuleGroupResponse: *ruleGroup.RuleGroupResponse,
+				RuleGroup:         ruleGroup.RuleGroup,
+				ResourceLocation:  loc,
+			})
+		}
+	}
+	return res, nil
+}
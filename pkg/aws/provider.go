@@ -0,0 +1,39 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aws
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/common"
+	"github.com/np-guard/cloud-resource-collector/pkg/tfstate"
+)
+
+// RegisterProvider registers the AWS provider, together with its collect flags
+func RegisterProvider() error {
+	options := &CollectOptions{}
+	flags := pflag.NewFlagSet(string(common.AWS), pflag.ContinueOnError)
+	flags.StringVar(&options.Profile, "aws-profile", "", "AWS shared config profile (possibly an SSO profile) to use")
+	flags.StringVar(&options.EndpointURL, "aws-endpoint-url", "",
+		"override the endpoint URL of all AWS services (e.g., for collecting from LocalStack)")
+	flags.StringVar(&options.RoleArn, "aws-role-arn", "", "ARN of an AWS role to assume for collecting resources")
+	flags.StringVar(&options.OrgRoleName, "aws-org-role-name", "",
+		"name of an AWS role to assume in each account of the organization, for collecting resources from all accounts")
+
+	return common.RegisterProvider(&common.ProviderInfo{
+		Name: common.AWS,
+		NewResourcesContainer: func(regions []string, _ string) common.ResourcesContainerInf {
+			return NewResourcesContainer(regions, options)
+		},
+		AllRegions: func() []string { return awsRegions },
+		FromTerraformState: func(state *tfstate.State) (common.ResourcesContainerInf, error) {
+			return FromTerraformState(state), nil
+		},
+		ExportTerraform: exportTerraform,
+		Flags:           flags,
+	})
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	r53r "github.com/aws/aws-sdk-go-v2/service/route53resolver"
+	r53rtypes "github.com/aws/aws-sdk-go-v2/service/route53resolver/types"
+)
+
+// ResolverEndpoint is an inbound or outbound Route 53 Resolver endpoint, together with its IP addresses (one per subnet)
+type ResolverEndpoint struct {
+	r53rtypes.ResolverEndpoint
+	IPAddresses []r53rtypes.IpAddressResponse
+	ResourceLocation
+}
+
+// ResolverRule is a Route 53 Resolver (forwarding) rule, together with its associations to VPCs
+type ResolverRule struct {
+	r53rtypes.ResolverRule
+	Associations []r53rtypes.ResolverRuleAssociation
+	ResourceLocation
+}
+
+// DNSFirewallRuleGroup is a Route 53 Resolver DNS Firewall rule group, together with its rules and its associations to VPCs
+type DNSFirewallRuleGroup struct {
+	r53rtypes.FirewallRuleGroup
+	Rules        []r53rtypes.FirewallRule
+	Associations []r53rtypes.FirewallRuleGroupAssociation
+	ResourceLocation
+}
+
+// collectResolverResources uses the Route 53 Resolver API to collect resolver endpoints, resolver rules
+// and DNS Firewall rule groups of a single region
+func (resources *ResourcesContainer) collectResolverResources(cfg *awssdk.Config, loc ResourceLocation) error {
+	client := r53r.NewFromConfig(*cfg)
+
+	endpoints, err := getResolverEndpoints(client, loc)
+	if err != nil {
+		return err
+	}
+	resources.ResolverEndpointsList = append(resources.ResolverEndpointsList, endpoints...)
+
+	rules, err := getResolverRules(client, loc)
+	if err != nil {
+		return err
+	}
+	resources.ResolverRulesList = append(resources.ResolverRulesList, rules...)
+
+	dnsFirewallRuleGroups, err := getDNSFirewallRuleGroups(client, loc)
+	if err != nil {
+		return err
+	}
+	resources.DNSFirewallRuleGroupsList = append(resources.DNSFirewallRuleGroupsList, dnsFirewallRuleGroups...)
+
+	return nil
+}
+
+func getResolverEndpoints(client *r53r.Client, loc ResourceLocation) ([]*ResolverEndpoint, error) {
+	res := []*ResolverEndpoint{}
+	paginator := r53r.NewListResolverEndpointsPaginator(client, &r53r.ListResolverEndpointsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getResolverEndpoints] error getting resolver endpoints: %w", err)
+		}
+		for i := range page.ResolverEndpoints {
+			ipAddresses, err := getResolverEndpointIPAddresses(client, page.ResolverEndpoints[i].Id)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, &ResolverEndpoint{
+				ResolverEndpoint: page.ResolverEndpoints[i],
+				IPAddresses:      ipAddresses,
+				ResourceLocation: loc,
+			})
+		}
+	}
+	return res, nil
+}
+
+func getResolverEndpointIPAddresses(client *r53r.Client, endpointID *string) ([]r53rtypes.IpAddressResponse, error) {
+	res := []r53rtypes.IpAddressResponse{}
+	paginator := r53r.NewListResolverEndpointIpAddressesPaginator(client,
+		&r53r.ListResolverEndpointIpAddressesInput{ResolverEndpointId: endpointID})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getResolverEndpointIPAddresses] error getting IP addresses for %s: %w", *endpointID, err)
+		}
+		res = append(res, page.IpAddresses...)
+	}
+	return res, nil
+}
+
+func getResolverRules(client *r53r.Client, loc ResourceLocation) ([]*ResolverRule, error) {
+	associations := map[string][]r53rtypes.ResolverRuleAssociation{}
+	associationsPaginator := r53r.NewListResolverRuleAssociationsPaginator(client, &r53r.ListResolverRuleAssociationsInput{})
+	for associationsPaginator.HasMorePages() {
+		page, err := associationsPaginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getResolverRules] error getting resolver rule associations: %w", err)
+		}
+		for i := range page.ResolverRuleAssociations {
+			ruleID := *page.ResolverRuleAssociations[i].ResolverRuleId
+			associations[ruleID] = append(associations[ruleID], page.ResolverRuleAssociations[i])
+		}
+	}
+
+	res := []*ResolverRule{}
+	paginator := r53r.NewListResolverRulesPaginator(client, &r53r.ListResolverRulesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getResolverRules] error getting resolver rules: %w", err)
+		}
+		for i := range page.ResolverRules {
+			rule := &ResolverRule{
+				ResolverRule:     page.ResolverRules[i],
+				Associations:     associations[*page.ResolverRules[i].Id],
+				ResourceLocation: loc,
+			}
+			if rule.Associations == nil {
+				rule.Associations = []r53rtypes.ResolverRuleAssociation{}
+			}
+			res = append(res, rule)
+		}
+	}
+	return res, nil
+}
+
+func getDNSFirewallRuleGroups(client *r53r.Client, loc ResourceLocation) ([]*DNSFirewallRuleGroup, error) {
+	associations := map[string][]r53rtypes.FirewallRuleGroupAssociation{}
+	associationsPaginator := r53r.NewListFirewallRuleGroupAssociationsPaginator(client, &r53r.ListFirewallRuleGroupAssociationsInput{})
+	for associationsPaginator.HasMorePages() {
+		page, err := associationsPaginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getDNSFirewallRuleGroups] error getting DNS firewall rule group associations: %w", err)
+		}
+		for i := range page.FirewallRuleGroupAssociations {
+			groupID := *page.FirewallRuleGroupAssociations[i].FirewallRuleGroupId
+			associations[groupID] = append(associations[groupID], page.FirewallRuleGroupAssociations[i])
+		}
+	}
+
+	res := []*DNSFirewallRuleGroup{}
+	paginator := r53r.NewListFirewallRuleGroupsPaginator(client, &r53r.ListFirewallRuleGroupsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getDNSFirewallRuleGroups] error listing DNS firewall rule groups: %w", err)
+		}
+		for i := range page.FirewallRuleGroups {
+			groupID := page.FirewallRuleGroups[i].Id
+			group, err := client.GetFirewallRuleGroup(context.TODO(), &r53r.GetFirewallRuleGroupInput{FirewallRuleGroupId: groupID})
+			if err != nil {
+				return nil, fmt.Errorf("[getDNSFirewallRuleGroups] error getting DNS firewall rule group %s: %w", *groupID, err)
+			}
+			rules, err := getDNSFirewallRules(client, groupID)
+			if err != nil {
+				return nil, err
+			}
+			ruleGroup := &DNSFirewallRuleGroup{
+				FirewallRuleGroup: *group.FirewallRuleGroup,
+				Rules:             rules,
+				Associations:      associations[*groupID],
+				ResourceLocation:  loc,
+			}
+			if ruleGroup.Associations == nil {
+				ruleGroup.Associations = []r53rtypes.FirewallRuleGroupAssociation{}
+			}
+			res = append(res, ruleGroup)
+		}
+	}
+	return res, nil
+}
+
+func getDNSFirewallRules(client *r53r.Client, groupID *string) ([]r53rtypes.FirewallRule, error) {
+	res := []r53rtypes.FirewallRule{}
+	paginator := r53r.NewListFirewallRulesPaginator(client, &r53r.ListFirewallRulesInput{FirewallRuleGroupId: groupID})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getDNSFirewallRules] error getting rules of DNS firewall rule group %s: %w", *groupID, err)
+		}
+		res = append(res, page.FirewallRules...)
+	}
+	return res, nil
+}
@@ -14,57 +14,133 @@ import (
 	"slices"
 	"strings"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
-	aws2 "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 
 	"github.com/np-guard/cloud-resource-collector/pkg/common"
 	"github.com/np-guard/cloud-resource-collector/pkg/version"
 )
 
-type VPC struct {
-	aws2.Vpc
-	Region string
-}
-
 // ResourcesContainer holds the results of collecting the configurations of all resources.
-// This includes: instances, internet gateways, network ACLs, security groups, subnets, and VPCs
+// This includes: instances, internet gateways, load balancers, network ACLs, prefix lists, security groups, subnets, VPCs,
+// VPN resources, network firewalls, Route 53 Resolver (DNS) resources and EKS clusters
 type ResourcesContainer struct {
 	common.ResourceModelMetadata
-	InstancesList      []*aws2.Instance        `json:"instances"`
-	InternetGWList     []*aws2.InternetGateway `json:"internet_gateways"`
-	NetworkACLsList    []*aws2.NetworkAcl      `json:"network_acls"`
-	SecurityGroupsList []*aws2.SecurityGroup   `json:"security_groups"`
-	SubnetsList        []*aws2.Subnet          `json:"subnets"`
-	VpcsList           []*VPC                  `json:"vpcs"`
-	regions            []string
+	ClassicLBsList            []*ClassicLoadBalancer  `json:"classic_load_balancers"`
+	ClientVpnEndpointsList    []*ClientVpnEndpoint    `json:"client_vpn_endpoints"`
+	CustomerGWList            []*CustomerGateway      `json:"customer_gateways"`
+	DNSFirewallRuleGroupsList []*DNSFirewallRuleGroup `json:"dns_firewall_rule_groups"`
+	EKSClustersList           []*EKSCluster           `json:"eks_clusters"`
+	FirewallPoliciesList      []*FirewallPolicy       `json:"firewall_policies"`
+	FirewallRuleGroupsList    []*FirewallRuleGroup    `json:"firewall_rule_groups"`
+	InstancesList             []*Instance             `json:"instances"`
+	InternetGWList            []*InternetGateway      `json:"internet_gateways"`
+	LBsList                   []*LoadBalancer         `json:"load_balancers"`
+	NetworkACLsList           []*NetworkACL           `json:"network_acls"`
+	NetworkFirewallsList      []*NetworkFirewall      `json:"network_firewalls"`
+	PrefixListsList           []*PrefixList           `json:"prefix_lists"`
+	ResolverEndpointsList     []*ResolverEndpoint     `json:"resolver_endpoints"`
+	ResolverRulesList         []*ResolverRule         `json:"resolver_rules"`
+	SecurityGroupsList        []*SecurityGroup        `json:"security_groups"`
+	SGRulesList               []*SecurityGroupRule    `json:"security_group_rules"`
+	SubnetsList               []*Subnet               `json:"subnets"`
+	TargetGroupsList          []*TargetGroup          `json:"target_groups"`
+	VpcsList                  []*VPC                  `json:"vpcs"`
+	VpnConnectionsList        []*VpnConnection        `json:"vpn_connections"`
+	VpnGWList                 []*VpnGateway           `json:"vpn_gateways"`
+	regions                   []string
+	options                   CollectOptions
+}
+
+// CollectOptions holds the options for collecting resources from more than the account of the default credentials
+type CollectOptions struct {
+	// RoleArn is the ARN of a role to assume (using the default credentials) for collecting resources
+	RoleArn string
+	// OrgRoleName is the name of a role, present in every account of the organization, to assume for collecting
+	// resources from all the organization's accounts. The default credentials (or RoleArn) must be of the
+	// organization's management account or of a delegated administrator
+	OrgRoleName string
+	// Profile is the name of a profile in the shared config files to use instead of the default profile.
+	// This can also be an SSO profile, after logging in using "aws sso login"
+	Profile string
+	// EndpointURL overrides the endpoint of all AWS services, e.g., for collecting from LocalStack
+	EndpointURL string
 }
 
 // NewResourcesContainer creates an empty resources container
-func NewResourcesContainer(regions []string) *ResourcesContainer {
+func NewResourcesContainer(regions []string, options *CollectOptions) *ResourcesContainer {
 	if len(regions) == 0 {
 		regions = awsRegions
 	}
+	if options == nil {
+		options = &CollectOptions{}
+	}
 	return &ResourcesContainer{
-		InstancesList:         []*aws2.Instance{},
-		InternetGWList:        []*aws2.InternetGateway{},
-		NetworkACLsList:       []*aws2.NetworkAcl{},
-		SecurityGroupsList:    []*aws2.SecurityGroup{},
-		SubnetsList:           []*aws2.Subnet{},
-		VpcsList:              []*VPC{},
-		ResourceModelMetadata: common.ResourceModelMetadata{Version: version.VersionCore, Provider: string(common.AWS)},
-		regions:               regions,
+		ClassicLBsList:            []*ClassicLoadBalancer{},
+		ClientVpnEndpointsList:    []*ClientVpnEndpoint{},
+		CustomerGWList:            []*CustomerGateway{},
+		DNSFirewallRuleGroupsList: []*DNSFirewallRuleGroup{},
+		EKSClustersList:           []*EKSCluster{},
+		FirewallPoliciesList:      []*FirewallPolicy{},
+		FirewallRuleGroupsList:    []*FirewallRuleGroup{},
+		InstancesList:             []*Instance{},
+		InternetGWList:            []*InternetGateway{},
+		LBsList:                   []*LoadBalancer{},
+		NetworkACLsList:           []*NetworkACL{},
+		NetworkFirewallsList:      []*NetworkFirewall{},
+		PrefixListsList:           []*PrefixList{},
+		ResolverEndpointsList:     []*ResolverEndpoint{},
+		ResolverRulesList:         []*ResolverRule{},
+		SecurityGroupsList:        []*SecurityGroup{},
+		SGRulesList:               []*SecurityGroupRule{},
+		SubnetsList:               []*Subnet{},
+		TargetGroupsList:          []*TargetGroup{},
+		VpcsList:                  []*VPC{},
+		VpnConnectionsList:        []*VpnConnection{},
+		VpnGWList:                 []*VpnGateway{},
+		ResourceModelMetadata:     common.ResourceModelMetadata{Version: version.VersionCore, Provider: string(common.AWS)},
+		regions:                   regions,
+		options:                   *options,
 	}
 }
 
 // PrintStats outputs the number of items of each type
 func (resources *ResourcesContainer) PrintStats() {
+	fmt.Printf("Found %d classic load balancers\n", len(resources.ClassicLBsList))
+	fmt.Printf("Found %d Client VPN endpoints\n", len(resources.ClientVpnEndpointsList))
+	fmt.Printf("Found %d customer gateways\n", len(resources.CustomerGWList))
+	fmt.Printf("Found %d DNS firewall rule groups\n", len(resources.DNSFirewallRuleGroupsList))
+	fmt.Printf("Found %d EKS clusters\n", len(resources.EKSClustersList))
+	fmt.Printf("Found %d network firewall policies\n", len(resources.FirewallPoliciesList))
+	fmt.Printf("Found %d network firewall rule groups\n", len(resources.FirewallRuleGroupsList))
 	fmt.Printf("Found %d instances\n", len(resources.InstancesList))
 	fmt.Printf("Found %d internet gateways\n", len(resources.InternetGWList))
+	fmt.Printf("Found %d load balancers\n", len(resources.LBsList))
 	fmt.Printf("Found %d nACLs\n", len(resources.NetworkACLsList))
+	fmt.Printf("Found %d network firewalls\n", len(resources.NetworkFirewallsList))
+	fmt.Printf("Found %d managed prefix lists\n", len(resources.PrefixListsList))
+	fmt.Printf("Found %d resolver endpoints\n", len(resources.ResolverEndpointsList))
+	fmt.Printf("Found %d resolver rules\n", len(resources.ResolverRulesList))
 	fmt.Printf("Found %d security groups\n", len(resources.SecurityGroupsList))
+	fmt.Printf("Found %d security group rules\n", len(resources.SGRulesList))
 	fmt.Printf("Found %d subnets\n", len(resources.SubnetsList))
+	fmt.Printf("Found %d target groups\n", len(resources.TargetGroupsList))
 	fmt.Printf("Found %d VPCs\n", len(resources.VpcsList))
+	fmt.Printf("Found %d VPN connections\n", len(resources.VpnConnectionsList))
+	fmt.Printf("Found %d VPN gateways\n", len(resources.VpnGWList))
+}
+
+// SecurityGroupRules returns the rules of the given security group, as collected using DescribeSecurityGroupRules.
+// This is an alternative view of the rules in the group's IpPermissions and IpPermissionsEgress
+func (resources *ResourcesContainer) SecurityGroupRules(groupID string) []*SecurityGroupRule {
+	res := []*SecurityGroupRule{}
+	for _, rule := range resources.SGRulesList {
+		if rule.GroupId != nil && *rule.GroupId == groupID {
+			res = append(res, rule)
+		}
+	}
+	return res
 }
 
 // ToJSONString converts a ResourcesContainer into a json-formatted-string
@@ -85,13 +161,38 @@ func (resources *ResourcesContainer) Fabricate(opts *common.FabricateOptions) {
 }
 
 // CollectResourcesFromAPI uses AWS APIs to collect resource configuration information
-func (resources *ResourcesContainer) CollectResourcesFromAPI() error { //nolint:gocyclo // due to many API calls
+func (resources *ResourcesContainer) CollectResourcesFromAPI() error {
 	// Load the Shared AWS Configuration (~/.aws/config)
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	var loadOptions []func(*config.LoadOptions) error
+	if resources.options.Profile != "" {
+		loadOptions = append(loadOptions, config.WithSharedConfigProfile(resources.options.Profile))
+	}
+	cfg, err := config.LoadDefaultConfig(context.TODO(), loadOptions...)
 	if err != nil {
 		return fmt.Errorf("CollectResourcesFromAPI encountered an error loading AWS the configuration: %w", err)
 	}
 
+	if resources.options.EndpointURL != "" {
+		cfg.BaseEndpoint = awssdk.String(resources.options.EndpointURL)
+	}
+
+	if resources.options.RoleArn != "" {
+		cfg.Credentials = assumeRoleCredentials(&cfg, resources.options.RoleArn)
+	}
+
+	if resources.options.OrgRoleName != "" {
+		return resources.collectOrganizationResources(&cfg)
+	}
+
+	identity, err := getCallerIdentity(&cfg)
+	if err != nil {
+		return err
+	}
+	return resources.collectAccountResources(&cfg, *identity.Account)
+}
+
+// collectAccountResources collects resources from all requested regions of a single account
+func (resources *ResourcesContainer) collectAccountResources(cfg *awssdk.Config, accountID string) error {
 	for _, region := range resources.regions {
 		if !slices.Contains(awsRegions, region) {
 			log.Printf("Unknown region %s. Available regions for provider aws: %s\n", region, strings.Join(awsRegions, ", "))
@@ -99,64 +200,119 @@ func (resources *ResourcesContainer) CollectResourcesFromAPI() error { //nolint:
 		}
 
 		log.Printf("Collecting resources from region %s\n", region)
-		cfg.Region = region
-		client := ec2.NewFromConfig(cfg) // Create an Amazon ec2 service client
-
-		// Get (the first page of) VPCs
-		vpcsFromAPI, err := client.DescribeVpcs(context.TODO(), &ec2.DescribeVpcsInput{})
+		regionalCfg := cfg.Copy()
+		regionalCfg.Region = region
+		loc := ResourceLocation{AccountID: accountID, Region: region}
+		client := ec2.NewFromConfig(regionalCfg) // Create an Amazon ec2 service client
+		err := resources.collectEC2Resources(client, loc)
 		if err != nil {
-			return fmt.Errorf("CollectResourcesFromAPI error getting VPCs: %w", err)
-		}
-		for i := range vpcsFromAPI.Vpcs {
-			vpc := VPC{Region: region, Vpc: vpcsFromAPI.Vpcs[i]}
-			resources.VpcsList = append(resources.VpcsList, &vpc)
+			return err
 		}
 
-		// Get (the first page of) Internet Gateways
-		intGWFromAPI, err := client.DescribeInternetGateways(context.TODO(), &ec2.DescribeInternetGatewaysInput{})
+		err = resources.collectVpnResources(client, loc)
 		if err != nil {
-			return fmt.Errorf("CollectResourcesFromAPI error getting internet gateways: %w", err)
-		}
-		for i := range intGWFromAPI.InternetGateways {
-			resources.InternetGWList = append(resources.InternetGWList, &intGWFromAPI.InternetGateways[i])
+			return err
 		}
 
-		// Get (the first page of) Subnets
-		subnetsFromAPI, err := client.DescribeSubnets(context.TODO(), &ec2.DescribeSubnetsInput{})
+		err = resources.collectLoadBalancers(&regionalCfg, loc)
 		if err != nil {
-			return fmt.Errorf("CollectResourcesFromAPI error getting subnets: %w", err)
-		}
-		for i := range subnetsFromAPI.Subnets {
-			resources.SubnetsList = append(resources.SubnetsList, &subnetsFromAPI.Subnets[i])
+			return err
 		}
 
-		// Get (the first page of) Network ACLs
-		nACLsFromAPI, err := client.DescribeNetworkAcls(context.TODO(), &ec2.DescribeNetworkAclsInput{})
+		err = resources.collectNetworkFirewalls(&regionalCfg, loc)
 		if err != nil {
-			return fmt.Errorf("CollectResourcesFromAPI error getting nACLs: %w", err)
-		}
-		for i := range nACLsFromAPI.NetworkAcls {
-			resources.NetworkACLsList = append(resources.NetworkACLsList, &nACLsFromAPI.NetworkAcls[i])
+			return err
 		}
 
-		// Get (the first page of) Security Groups
-		secGroupsFromAPI, err := client.DescribeSecurityGroups(context.TODO(), &ec2.DescribeSecurityGroupsInput{})
+		err = resources.collectResolverResources(&regionalCfg, loc)
 		if err != nil {
-			return fmt.Errorf("CollectResourcesFromAPI error getting security groups: %w", err)
-		}
-		for i := range secGroupsFromAPI.SecurityGroups {
-			resources.SecurityGroupsList = append(resources.SecurityGroupsList, &secGroupsFromAPI.SecurityGroups[i])
+			return err
 		}
 
-		// Get (the first page of) Instances
-		instancesFromAPI, err := client.DescribeInstances(context.TODO(), &ec2.DescribeInstancesInput{})
+		err = resources.collectEKSClusters(&regionalCfg, loc)
 		if err != nil {
-			return fmt.Errorf("CollectResourcesFromAPI error getting instances: %w", err)
+			return err
 		}
-		for i := range instancesFromAPI.Reservations {
-			for j := range instancesFromAPI.Reservations[i].Instances {
-				resources.InstancesList = append(resources.InstancesList, &instancesFromAPI.Reservations[i].Instances[j])
-			}
+	}
+
+	return nil
+}
+
+// collectEC2Resources uses the EC2 API to collect the VPC-related resources of a single region
+func (resources *ResourcesContainer) collectEC2Resources(client *ec2.Client, loc ResourceLocation) error {
+	// Get (the first page of) VPCs
+	vpcsFromAPI, err := client.DescribeVpcs(context.TODO(), &ec2.DescribeVpcsInput{})
+	if err != nil {
+		return fmt.Errorf("CollectResourcesFromAPI error getting VPCs: %w", err)
+	}
+	for i := range vpcsFromAPI.Vpcs {
+		vpc := VPC{Vpc: vpcsFromAPI.Vpcs[i], ResourceLocation: loc}
+		resources.VpcsList = append(resources.VpcsList, &vpc)
+	}
+
+	// Get (the first page of) Internet Gateways
+	intGWFromAPI, err := client.DescribeInternetGateways(context.TODO(), &ec2.DescribeInternetGatewaysInput{})
+	if err != nil {
+		return fmt.Errorf("CollectResourcesFromAPI error getting internet gateways: %w", err)
+	}
+	for i := range intGWFromAPI.InternetGateways {
+		intGW := InternetGateway{InternetGateway: intGWFromAPI.InternetGateways[i], ResourceLocation: loc}
+		resources.InternetGWList = append(resources.InternetGWList, &intGW)
+	}
+
+	// Get (the first page of) Subnets
+	subnetsFromAPI, err := client.DescribeSubnets(context.TODO(), &ec2.DescribeSubnetsInput{})
+	if err != nil {
+		return fmt.Errorf("CollectResourcesFromAPI error getting subnets: %w", err)
+	}
+	for i := range subnetsFromAPI.Subnets {
+		subnet := Subnet{Subnet: subnetsFromAPI.Subnets[i], ResourceLocation: loc}
+		resources.SubnetsList = append(resources.SubnetsList, &subnet)
+	}
+
+	// Get (the first page of) Network ACLs
+	nACLsFromAPI, err := client.DescribeNetworkAcls(context.TODO(), &ec2.DescribeNetworkAclsInput{})
+	if err != nil {
+		return fmt.Errorf("CollectResourcesFromAPI error getting nACLs: %w", err)
+	}
+	for i := range nACLsFromAPI.NetworkAcls {
+		nACL := NetworkACL{NetworkAcl: nACLsFromAPI.NetworkAcls[i], ResourceLocation: loc}
+		resources.NetworkACLsList = append(resources.NetworkACLsList, &nACL)
+	}
+
+	// Get (the first page of) Security Groups
+	secGroupsFromAPI, err := client.DescribeSecurityGroups(context.TODO(), &ec2.DescribeSecurityGroupsInput{})
+	if err != nil {
+		return fmt.Errorf("CollectResourcesFromAPI error getting security groups: %w", err)
+	}
+	for i := range secGroupsFromAPI.SecurityGroups {
+		secGroup := SecurityGroup{SecurityGroup: secGroupsFromAPI.SecurityGroups[i], ResourceLocation: loc}
+		resources.SecurityGroupsList = append(resources.SecurityGroupsList, &secGroup)
+	}
+
+	// Get all Security Group Rules (the same rules as in IpPermissions, but one rule per entry, each with its ID)
+	sgRules, err := getSecurityGroupRules(client, loc)
+	if err != nil {
+		return err
+	}
+	resources.SGRulesList = append(resources.SGRulesList, sgRules...)
+
+	// Get all managed prefix lists, so that references to them in rules and routes can be resolved into CIDRs
+	prefixLists, err := getPrefixLists(client, loc)
+	if err != nil {
+		return err
+	}
+	resources.PrefixListsList = append(resources.PrefixListsList, prefixLists...)
+
+	// Get (the first page of) Instances
+	instancesFromAPI, err := client.DescribeInstances(context.TODO(), &ec2.DescribeInstancesInput{})
+	if err != nil {
+		return fmt.Errorf("CollectResourcesFromAPI error getting instances: %w", err)
+	}
+	for i := range instancesFromAPI.Reservations {
+		for j := range instancesFromAPI.Reservations[i].Instances {
+			instance := Instance{Instance: instancesFromAPI.Reservations[i].Instances[j], ResourceLocation: loc}
+			resources.InstancesList = append(resources.InstancesList, &instance)
 		}
 	}
 
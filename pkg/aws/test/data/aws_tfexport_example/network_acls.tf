@@ -0,0 +1,38 @@
+resource "aws_network_acl" "acl-0a1b2c3d4e5f60005" {
+  vpc_id     = aws_vpc.main.id
+  subnet_ids = [aws_subnet.subnet-0a1b2c3d4e5f60003.id]
+  egress {
+    protocol   = "-1"
+    rule_no    = 100
+    action     = "allow"
+    cidr_block = "10.0.0.0/16"
+    from_port  = 0
+    to_port    = 0
+  }
+  ingress {
+    protocol   = "6"
+    rule_no    = 90
+    action     = "deny"
+    cidr_block = "0.0.0.0/0"
+    from_port  = 22
+    to_port    = 22
+  }
+  ingress {
+    protocol   = "6"
+    rule_no    = 100
+    action     = "allow"
+    cidr_block = "10.0.1.0/24"
+    from_port  = 5432
+    to_port    = 5432
+  }
+  ingress {
+    protocol   = "1"
+    rule_no    = 110
+    action     = "allow"
+    cidr_block = "10.0.0.0/16"
+    from_port  = 0
+    to_port    = 0
+    icmp_type  = 8
+    icmp_code  = 0
+  }
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/aws"
+)
+
+const (
+	tfExportExpectedDir = "data/aws_tfexport_example"
+	tfExportOutputDir   = "tfexport_output"
+)
+
+func TestExportTerraform(t *testing.T) {
+	snapshot, err := os.ReadFile(tfStateExpectedFile)
+	if err != nil {
+		t.Fatalf("couldn't read file: %s", tfStateExpectedFile)
+	}
+	resources := aws.ResourcesContainer{}
+	err = json.Unmarshal(snapshot, &resources)
+	if err != nil {
+		t.Fatalf("Unmarshal failed with error message: %v", err)
+	}
+	config := aws.ExportTerraform(&resources)
+
+	expectedFiles, err := os.ReadDir(tfExportExpectedDir)
+	if err != nil {
+		t.Fatalf("couldn't read directory: %s", tfExportExpectedDir)
+	}
+	if len(expectedFiles) != len(config.FileNames()) {
+		t.Errorf("Exported files %v differ from the files in %s", config.FileNames(), tfExportExpectedDir)
+	}
+	files := config.Files()
+	for _, name := range config.FileNames() {
+		expected, readErr := os.ReadFile(filepath.Join(tfExportExpectedDir, name))
+		if readErr == nil && string(expected) == string(files[name]) {
+			continue
+		}
+		t.Errorf("Exported %s differs from %s", name, tfExportExpectedDir)
+
+		// Used for debugging test failures:
+		err = os.MkdirAll(tfExportOutputDir, 0o755)
+		if err == nil {
+			err = os.WriteFile(filepath.Join(tfExportOutputDir, name), files[name], 0o600)
+		}
+		if err != nil {
+			t.Errorf("failed with error %v", err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/aws"
+	"github.com/np-guard/cloud-resource-collector/pkg/tfstate"
+)
+
+const (
+	tfStateFile         = "data/terraform.tfstate"
+	tfStateExpectedFile = "data/aws_tfstate_example.json"
+)
+
+func TestFromTerraformState(t *testing.T) {
+	state, err := tfstate.Load(tfStateFile)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	toPrint, err := aws.FromTerraformState(state).ToJSONString()
+	if err != nil {
+		t.Fatalf("ToJSONString failed: %v", err)
+	}
+
+	expected, err := os.ReadFile(tfStateExpectedFile)
+	if err != nil {
+		t.Fatalf("couldn't read file: %s", tfStateExpectedFile)
+	}
+	if string(expected) != toPrint {
+		t.Errorf("Translated resources differ from %s", tfStateExpectedFile)
+
+		// Used for debugging test failures:
+		err = os.WriteFile("tfstate_output.json", []byte(toPrint), 0o600)
+		if err != nil {
+			t.Errorf("failed with error %v", err)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	aws2 "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/tfexport"
+)
+
+// Terraform resource types generated by ExportTerraform, in addition to those translated by FromTerraformState
+const tfVPCCidrBlockAssociation = "aws_vpc_ipv4_cidr_block_association"
+
+// Files of the generated configuration
+const (
+	tfProviderName         = "aws"
+	tfProviderSource       = "hashicorp/aws"
+	tfVPCsFile             = "vpcs"
+	tfSubnetsFile          = "subnets"
+	tfInternetGatewaysFile = "internet_gateways"
+	tfNetworkACLsFile      = "network_acls"
+	tfSecurityGroupsFile   = "security_groups"
+)
+
+const (
+	nameTag           = "Name"
+	reservedTagPrefix = "aws:"
+	// the name of the security group that AWS creates in each VPC
+	defaultSecurityGroupName = "default"
+)
+
+// tfExporter generates the Terraform resources recreating the VPC resources of a snapshot.
+// Resources reference each other by their IDs in the snapshot, which the configuration maps to Terraform references
+type tfExporter struct {
+	config    *tfexport.Config
+	resources *ResourcesContainer
+	// the secondary CIDR block associations exported for each VPC (the subnets of a VPC depend on these)
+	vpcCidrAssociations map[string][]string
+}
+
+// ExportTerraform generates a Terraform configuration recreating the VPCs in the given resources, with their CIDR
+// blocks, subnets, internet gateways, network ACLs and security groups. Default network ACLs and security groups
+// are managed through the defaults of the exported VPCs. Resources from all accounts are exported to a single
+// account; other resources (e.g., instances and load balancers) are not exported
+func ExportTerraform(resources *ResourcesContainer) *tfexport.Config {
+	e := &tfExporter{config: tfexport.NewConfig(), resources: resources, vpcCidrAssociations: map[string][]string{}}
+	regions := []string{}
+	for _, vpc := range resources.VpcsList {
+		if vpc.Region != "" && !slices.Contains(regions, vpc.Region) {
+			regions = append(regions, vpc.Region)
+		}
+	}
+	slices.Sort(regions)
+	e.config.AddProvider(tfProviderName, tfProviderSource, regions)
+
+	// resources are exported after the resources they reference
+	e.exportVPCs()
+	e.exportSubnets()
+	e.exportInternetGateways()
+	e.exportNetworkACLs()
+	e.exportSecurityGroups()
+	return e.config
+}
+
+// exportTerraform generates a Terraform configuration from the content of an AWS snapshot
+func exportTerraform(snapshot []byte) (*tfexport.Config, error) {
+	resources := NewResourcesContainer(nil, nil)
+	if err := json.Unmarshal(snapshot, resources); err != nil {
+		return nil, fmt.Errorf("[exportTerraform] error parsing AWS snapshot: %w", err)
+	}
+	return ExportTerraform(resources), nil
+}
+
+func (e *tfExporter) exportVPCs() {
+	for _, vpc := range e.resources.VpcsList {
+		vpcID := awssdk.ToString(vpc.VpcId)
+		body := e.config.AddResource(tfVPCsFile, tfVPC, resourceName(vpcID, vpc.Tags), vpcID, vpc.Region)
+		tfexport.SetString(body, "cidr_block", vpc.CidrBlock)
+		exportTags(body, vpc.Tags)
+
+		for i := range vpc.CidrBlockAssociationSet {
+			association := &vpc.CidrBlockAssociationSet[i]
+			if awssdk.ToString(association.CidrBlock) == awssdk.ToString(vpc.CidrBlock) ||
+				association.CidrBlockState == nil || association.CidrBlockState.State != aws2.VpcCidrBlockStateCodeAssociated {
+				continue
+			}
+			associationID := awssdk.ToString(association.AssociationId)
+			associationBody := e.config.AddResource(tfVPCsFile, tfVPCCidrBlockAssociation, associationID, associationID, vpc.Region)
+			associationBody.SetAttributeRaw("vpc_id", e.config.Ref(vpcID, "id"))
+			tfexport.SetString(associationBody, "cidr_block", association.CidrBlock)
+			e.vpcCidrAssociations[vpcID] = append(e.vpcCidrAssociations[vpcID], associationID)
+		}
+	}
+}
+
+func (e *tfExporter) exportSubnets() {
+	for _, subnet := range e.resources.SubnetsList {
+		subnetID := awssdk.ToString(subnet.SubnetId)
+		vpcID := awssdk.ToString(subnet.VpcId)
+		body := e.config.AddResource(tfSubnetsFile, tfSubnet, resourceName(subnetID, subnet.Tags), subnetID, subnet.Region)
+		body.SetAttributeRaw("vpc_id", e.config.Ref(vpcID, "id"))
+		tfexport.SetString(body, "cidr_block", subnet.CidrBlock)
+		tfexport.SetString(body, "availability_zone", subnet.AvailabilityZone)
+		if awssdk.ToBool(subnet.MapPublicIpOnLaunch) {
+			body.SetAttributeValue("map_public_ip_on_launch", cty.True)
+		}
+		exportTags(body, subnet.Tags)
+		if associations := e.vpcCidrAssociations[vpcID]; len(associations) > 0 {
+			body.SetAttributeRaw("depends_on", e.config.Refs(associations, ""))
+		}
+	}
+}
+
+func (e *tfExporter) exportInternetGateways() {
+	for _, igw := range e.resources.InternetGWList {
+		igwID := awssdk.ToString(igw.InternetGatewayId)
+		body := e.config.AddResource(tfInternetGatewaysFile, tfInternetGateway, resourceName(igwID, igw.Tags), igwID, igw.Region)
+		if len(igw.Attachments) > 0 {
+			body.SetAttributeRaw("vpc_id", e.config.Ref(awssdk.ToString(igw.Attachments[0].VpcId), "id"))
+		}
+		exportTags(body, igw.Tags)
+	}
+}
+
+// exportNetworkACLs exports the network ACLs of all VPCs. The rules of a default network ACL are set on the default
+// network ACL of the exported VPC. The implicit deny rules are not exported
+func (e *tfExporter) exportNetworkACLs() {
+	for _, acl := range e.resources.NetworkACLsList {
+		aclID := awssdk.ToString(acl.NetworkAclId)
+		vpcID := awssdk.ToString(acl.VpcId)
+		var body *hclwrite.Body
+		if awssdk.ToBool(acl.IsDefault) {
+			body = e.config.AddResource(tfNetworkACLsFile, tfDefaultNetworkACL, resourceName(aclID, acl.Tags), aclID, acl.Region)
+			body.SetAttributeRaw("default_network_acl_id", e.config.Ref(vpcID, "default_network_acl_id"))
+		} else {
+			body = e.config.AddResource(tfNetworkACLsFile, tfNetworkACL, resourceName(aclID, acl.Tags), aclID, acl.Region)
+			body.SetAttributeRaw("vpc_id", e.config.Ref(vpcID, "id"))
+		}
+		subnetIDs := []string{}
+		for i := range acl.Associations {
+			subnetIDs = append(subnetIDs, awssdk.ToString(acl.Associations[i].SubnetId))
+		}
+		if len(subnetIDs) > 0 {
+			body.SetAttributeRaw("subnet_ids", e.config.Refs(subnetIDs, "id"))
+		}
+		exportTags(body, acl.Tags)
+		for i := range acl.Entries {
+			entry := &acl.Entries[i]
+			if awssdk.ToInt32(entry.RuleNumber) == implicitDenyRuleNumber {
+				continue
+			}
+			exportNetworkACLEntry(body, entry)
+		}
+	}
+}
+
+func exportNetworkACLEntry(body *hclwrite.Body, entry *aws2.NetworkAclEntry) {
+	blockType := "ingress"
+	if awssdk.ToBool(entry.Egress) {
+		blockType = "egress"
+	}
+	entryBody := body.AppendNewBlock(blockType, nil).Body()
+	tfexport.SetString(entryBody, "protocol", entry.Protocol)
+	tfexport.SetInt(entryBody, "rule_no", entry.RuleNumber)
+	entryBody.SetAttributeValue("action", cty.StringVal(string(entry.RuleAction)))
+	tfexport.SetString(entryBody, "cidr_block", entry.CidrBlock)
+	tfexport.SetString(entryBody, "ipv6_cidr_block", entry.Ipv6CidrBlock)
+	var fromPort, toPort int64
+	if entry.PortRange != nil {
+		fromPort, toPort = int64(awssdk.ToInt32(entry.PortRange.From)), int64(awssdk.ToInt32(entry.PortRange.To))
+	}
+	entryBody.SetAttributeValue("from_port", cty.NumberIntVal(fromPort))
+	entryBody.SetAttributeValue("to_port", cty.NumberIntVal(toPort))
+	if entry.IcmpTypeCode != nil {
+		tfexport.SetInt(entryBody, "icmp_type", entry.IcmpTypeCode.Type)
+		tfexport.SetInt(entryBody, "icmp_code", entry.IcmpTypeCode.Code)
+	}
+}
+
+// exportSecurityGroups exports all security groups before their rules, as rules may reference other groups.
+// Rules are exported as separate rule resources (one for each remote), except for the rules of default security
+// groups, which are inline rules of aws_default_security_group
+func (e *tfExporter) exportSecurityGroups() {
+	defaultSGs := map[string]*hclwrite.Body{}
+	for _, sg := range e.resources.SecurityGroupsList {
+		sgID := awssdk.ToString(sg.GroupId)
+		name := resourceName(awssdk.ToString(sg.GroupName), sg.Tags)
+		if awssdk.ToString(sg.GroupName) == defaultSecurityGroupName {
+			defaultSGs[sgID] = e.config.AddResource(tfSecurityGroupsFile, tfDefaultSecurityGroup, name, sgID, sg.Region)
+			continue
+		}
+		body := e.config.AddResource(tfSecurityGroupsFile, tfSecurityGroup, name, sgID, sg.Region)
+		tfexport.SetString(body, "name", sg.GroupName)
+		tfexport.SetString(body, "description", sg.Description)
+		body.SetAttributeRaw("vpc_id", e.config.Ref(awssdk.ToString(sg.VpcId), "id"))
+		exportTags(body, sg.Tags)
+	}
+	for _, sg := range e.resources.SecurityGroupsList {
+		sgID := awssdk.ToString(sg.GroupId)
+		if body, ok := defaultSGs[sgID]; ok {
+			e.exportDefaultSecurityGroup(body, sg)
+			continue
+		}
+		for i := range sg.IpPermissions {
+			e.exportSecurityGroupRules(tfSecurityGroupIngressRule, sg, &sg.IpPermissions[i])
+		}
+		for i := range sg.IpPermissionsEgress {
+			e.exportSecurityGroupRules(tfSecurityGroupEgressRule, sg, &sg.IpPermissionsEgress[i])
+		}
+	}
+}
+
+func (e *tfExporter) exportDefaultSecurityGroup(body *hclwrite.Body, sg *SecurityGroup) {
+	body.SetAttributeRaw("vpc_id", e.config.Ref(awssdk.ToString(sg.VpcId), "id"))
+	exportTags(body, sg.Tags)
+	for i := range sg.IpPermissions {
+		e.exportInlineSecurityGroupRule(body.AppendNewBlock("ingress", nil).Body(), sg, &sg.IpPermissions[i])
+	}
+	for i := range sg.IpPermissionsEgress {
+		e.exportInlineSecurityGroupRule(body.AppendNewBlock("egress", nil).Body(), sg, &sg.IpPermissionsEgress[i])
+	}
+}
+
+func (e *tfExporter) exportInlineSecurityGroupRule(body *hclwrite.Body, sg *SecurityGroup, permission *aws2.IpPermission) {
+	protocol := awssdk.ToString(permission.IpProtocol)
+	body.SetAttributeValue("protocol", cty.StringVal(protocol))
+	fromPort, toPort := int64(0), int64(0)
+	if protocol != allProtocols {
+		fromPort, toPort = int64(awssdk.ToInt32(permission.FromPort)), int64(awssdk.ToInt32(permission.ToPort))
+	}
+	body.SetAttributeValue("from_port", cty.NumberIntVal(fromPort))
+	body.SetAttributeValue("to_port", cty.NumberIntVal(toPort))
+
+	cidrs, ipv6Cidrs, prefixLists, groups := []string{}, []string{}, []string{}, []string{}
+	for i := range permission.IpRanges {
+		cidrs = append(cidrs, awssdk.ToString(permission.IpRanges[i].CidrIp))
+	}
+	for i := range permission.Ipv6Ranges {
+		ipv6Cidrs = append(ipv6Cidrs, awssdk.ToString(permission.Ipv6Ranges[i].CidrIpv6))
+	}
+	for i := range permission.PrefixListIds {
+		prefixLists = append(prefixLists, awssdk.ToString(permission.PrefixListIds[i].PrefixListId))
+	}
+	for i := range permission.UserIdGroupPairs {
+		if groupID := awssdk.ToString(permission.UserIdGroupPairs[i].GroupId); groupID == awssdk.ToString(sg.GroupId) {
+			body.SetAttributeValue("self", cty.True)
+		} else {
+			groups = append(groups, groupID)
+		}
+	}
+	tfexport.SetStrings(body, "cidr_blocks", cidrs)
+	tfexport.SetStrings(body, "ipv6_cidr_blocks", ipv6Cidrs)
+	tfexport.SetStrings(body, "prefix_list_ids", prefixLists)
+	if len(groups) > 0 {
+		body.SetAttributeRaw("security_groups", e.config.Refs(groups, "id"))
+	}
+}
+
+// exportSecurityGroupRules exports a rule resource for each remote (CIDR block, prefix list or security group)
+// of a permission
+func (e *tfExporter) exportSecurityGroupRules(resourceType string, sg *SecurityGroup, permission *aws2.IpPermission) {
+	sgID := awssdk.ToString(sg.GroupId)
+	addRule := func(description *string) *hclwrite.Body {
+		body := e.config.AddResource(tfSecurityGroupsFile, resourceType, resourceName(awssdk.ToString(sg.GroupName), sg.Tags), "", sg.Region)
+		body.SetAttributeRaw("security_group_id", e.config.Ref(sgID, "id"))
+		protocol := awssdk.ToString(permission.IpProtocol)
+		body.SetAttributeValue("ip_protocol", cty.StringVal(protocol))
+		if protocol != allProtocols {
+			tfexport.SetInt(body, "from_port", permission.FromPort)
+			tfexport.SetInt(body, "to_port", permission.ToPort)
+		}
+		tfexport.SetString(body, "description", description)
+		return body
+	}
+	for i := range permission.IpRanges {
+		tfexport.SetString(addRule(permission.IpRanges[i].Description), "cidr_ipv4", permission.IpRanges[i].CidrIp)
+	}
+	for i := range permission.Ipv6Ranges {
+		tfexport.SetString(addRule(permission.Ipv6Ranges[i].Description), "cidr_ipv6", permission.Ipv6Ranges[i].CidrIpv6)
+	}
+	for i := range permission.PrefixListIds {
+		tfexport.SetString(addRule(permission.PrefixListIds[i].Description), "prefix_list_id",
+			permission.PrefixListIds[i].PrefixListId)
+	}
+	for i := range permission.UserIdGroupPairs {
+		pair := &permission.UserIdGroupPairs[i]
+		addRule(pair.Description).SetAttributeRaw("referenced_security_group_id", e.config.Ref(awssdk.ToString(pair.GroupId), "id"))
+	}
+}
+
+// exportTags exports the tags of a resource, except for tags reserved by AWS
+func exportTags(body *hclwrite.Body, resourceTags []aws2.Tag) {
+	tagMap := map[string]string{}
+	for i := range resourceTags {
+		if key := awssdk.ToString(resourceTags[i].Key); !strings.HasPrefix(key, reservedTagPrefix) {
+			tagMap[key] = awssdk.ToString(resourceTags[i].Value)
+		}
+	}
+	tfexport.SetStringMap(body, "tags", tagMap)
+}
+
+// resourceName returns the value of the Name tag of a resource, or the given default name (e.g., its ID) if it has no
+// Name tag
+func resourceName(defaultName string, resourceTags []aws2.Tag) string {
+	for i := range resourceTags {
+		if awssdk.ToString(resourceTags[i].Key) == nameTag {
+			return awssdk.ToString(resourceTags[i].Value)
+		}
+	}
+	return defaultName
+}
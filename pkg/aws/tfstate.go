@@ -0,0 +1,453 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aws
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	aws2 "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/tfstate"
+)
+
+// Terraform resource types translated by FromTerraformState
+const (
+	tfVPC                      = "aws_vpc"
+	tfSubnet                   = "aws_subnet"
+	tfInternetGateway          = "aws_internet_gateway"
+	tfNetworkACL               = "aws_network_acl"
+	tfDefaultNetworkACL        = "aws_default_network_acl"
+	tfNetworkACLRule           = "aws_network_acl_rule"
+	tfSecurityGroup            = "aws_security_group"
+	tfDefaultSecurityGroup     = "aws_default_security_group"
+	tfSecurityGroupRule        = "aws_security_group_rule"
+	tfSecurityGroupIngressRule = "aws_vpc_security_group_ingress_rule"
+	tfSecurityGroupEgressRule  = "aws_vpc_security_group_egress_rule"
+	tfInstance                 = "aws_instance"
+)
+
+const (
+	allProtocols                 = "-1"
+	anyIPv4                      = "0.0.0.0/0"
+	implicitDenyRuleNumber int32 = 32767
+	// the state of an attached internet gateway, as returned by the API (not one of the aws2.AttachmentStatus values)
+	attachedIGWState aws2.AttachmentStatus = "available"
+)
+
+// Protocol numbers, as used by network ACL entries
+var protocolNumbers = map[string]string{"all": allProtocols, "tcp": "6", "udp": "17", "icmp": "1", "icmpv6": "58"}
+
+// tfTranslator translates AWS resources in a Terraform state into the data model
+type tfTranslator struct {
+	state *tfstate.State
+	// VPC of each subnet, and name of each security group, for completing references to them
+	subnetVPCs map[string]string
+	sgNames    map[string]string
+}
+
+// FromTerraformState creates a resources container holding the VPC resources in a Terraform state.
+// The account and the region of each resource are taken from its ARN. Resources that AWS creates implicitly
+// (e.g., the default network ACL of a VPC) are included only if they are managed by Terraform.
+// Security group rules appear in the IpPermissions of their groups; SGRulesList is left empty
+func FromTerraformState(state *tfstate.State) *ResourcesContainer {
+	t := &tfTranslator{state: state, subnetVPCs: map[string]string{}, sgNames: map[string]string{}}
+	for _, attrs := range state.Resources(tfSubnet) {
+		t.subnetVPCs[attrs.String("id")] = attrs.String("vpc_id")
+	}
+	for _, attrs := range t.resources(tfSecurityGroup, tfDefaultSecurityGroup) {
+		t.sgNames[attrs.String("id")] = attrs.String("name")
+	}
+
+	resources := NewResourcesContainer(nil, nil)
+	resources.VpcsList = t.vpcs()
+	resources.SubnetsList = t.subnets()
+	resources.InternetGWList = t.internetGateways()
+	resources.NetworkACLsList = t.networkACLs()
+	resources.SecurityGroupsList = t.securityGroups()
+	resources.InstancesList = t.instances()
+	return resources
+}
+
+func (t *tfTranslator) resources(resourceTypes ...string) []tfstate.Attributes {
+	res := []tfstate.Attributes{}
+	for _, resourceType := range resourceTypes {
+		res = append(res, t.state.Resources(resourceType)...)
+	}
+	return res
+}
+
+func (t *tfTranslator) vpcs() []*VPC {
+	res := []*VPC{}
+	for _, attrs := range t.state.Resources(tfVPC) {
+		vpc := aws2.Vpc{
+			VpcId:                       attrs.StringPtr("id"),
+			OwnerId:                     attrs.StringPtr("owner_id"),
+			CidrBlock:                   attrs.StringPtr("cidr_block"),
+			DhcpOptionsId:               attrs.StringPtr("dhcp_options_id"),
+			InstanceTenancy:             aws2.Tenancy(attrs.String("instance_tenancy")),
+			State:                       aws2.VpcStateAvailable,
+			Tags:                        tags(attrs),
+			CidrBlockAssociationSet:     []aws2.VpcCidrBlockAssociation{{CidrBlock: attrs.StringPtr("cidr_block")}},
+			Ipv6CidrBlockAssociationSet: []aws2.VpcIpv6CidrBlockAssociation{},
+		}
+		if ipv6 := attrs.StringPtr("ipv6_cidr_block"); ipv6 != nil {
+			vpc.Ipv6CidrBlockAssociationSet = append(vpc.Ipv6CidrBlockAssociationSet, aws2.VpcIpv6CidrBlockAssociation{
+				Ipv6CidrBlock: ipv6, AssociationId: attrs.StringPtr("ipv6_association_id")})
+		}
+		res = append(res, &VPC{Vpc: vpc, ResourceLocation: location(attrs)})
+	}
+	return res
+}
+
+func (t *tfTranslator) subnets() []*Subnet {
+	res := []*Subnet{}
+	for _, attrs := range t.state.Resources(tfSubnet) {
+		subnet := aws2.Subnet{
+			SubnetId:                    attrs.StringPtr("id"),
+			SubnetArn:                   attrs.StringPtr("arn"),
+			VpcId:                       attrs.StringPtr("vpc_id"),
+			OwnerId:                     attrs.StringPtr("owner_id"),
+			CidrBlock:                   attrs.StringPtr("cidr_block"),
+			AvailabilityZone:            attrs.StringPtr("availability_zone"),
+			AvailabilityZoneId:          attrs.StringPtr("availability_zone_id"),
+			MapPublicIpOnLaunch:         attrs.BoolPtr("map_public_ip_on_launch"),
+			AssignIpv6AddressOnCreation: attrs.BoolPtr("assign_ipv6_address_on_creation"),
+			State:                       aws2.SubnetStateAvailable,
+			Tags:                        tags(attrs),
+			Ipv6CidrBlockAssociationSet: []aws2.SubnetIpv6CidrBlockAssociation{},
+		}
+		if ipv6 := attrs.StringPtr("ipv6_cidr_block"); ipv6 != nil {
+			subnet.Ipv6CidrBlockAssociationSet = append(subnet.Ipv6CidrBlockAssociationSet, aws2.SubnetIpv6CidrBlockAssociation{
+				Ipv6CidrBlock: ipv6, AssociationId: attrs.StringPtr("ipv6_cidr_block_association_id")})
+		}
+		res = append(res, &Subnet{Subnet: subnet, ResourceLocation: location(attrs)})
+	}
+	return res
+}
+
+func (t *tfTranslator) internetGateways() []*InternetGateway {
+	res := []*InternetGateway{}
+	for _, attrs := range t.state.Resources(tfInternetGateway) {
+		igw := aws2.InternetGateway{
+			InternetGatewayId: attrs.StringPtr("id"),
+			OwnerId:           attrs.StringPtr("owner_id"),
+			Attachments:       []aws2.InternetGatewayAttachment{},
+			Tags:              tags(attrs),
+		}
+		if vpcID := attrs.StringPtr("vpc_id"); vpcID != nil {
+			igw.Attachments = append(igw.Attachments, aws2.InternetGatewayAttachment{VpcId: vpcID, State: attachedIGWState})
+		}
+		res = append(res, &InternetGateway{InternetGateway: igw, ResourceLocation: location(attrs)})
+	}
+	return res
+}
+
+func (t *tfTranslator) networkACLs() []*NetworkACL {
+	res := []*NetworkACL{}
+	for _, attrs := range t.resources(tfNetworkACL, tfDefaultNetworkACL) {
+		acl := aws2.NetworkAcl{
+			NetworkAclId: attrs.StringPtr("id"),
+			VpcId:        attrs.StringPtr("vpc_id"),
+			OwnerId:      attrs.StringPtr("owner_id"),
+			IsDefault:    awssdk.Bool(attrs.String("default_network_acl_id") != ""),
+			Associations: []aws2.NetworkAclAssociation{},
+			Entries:      []aws2.NetworkAclEntry{},
+			Tags:         tags(attrs),
+		}
+		for _, subnetID := range attrs.Strings("subnet_ids") {
+			acl.Associations = append(acl.Associations,
+				aws2.NetworkAclAssociation{NetworkAclId: acl.NetworkAclId, SubnetId: awssdk.String(subnetID)})
+		}
+		for _, entryAttrs := range attrs.Blocks("egress") {
+			acl.Entries = append(acl.Entries, networkACLEntry(entryAttrs, "rule_no", "action", true))
+		}
+		for _, entryAttrs := range attrs.Blocks("ingress") {
+			acl.Entries = append(acl.Entries, networkACLEntry(entryAttrs, "rule_no", "action", false))
+		}
+		// rules managed as separate resources may also appear in the ingress and egress blocks, once the state is refreshed
+		for _, ruleAttrs := range t.state.Resources(tfNetworkACLRule) {
+			if ruleAttrs.String("network_acl_id") != attrs.String("id") {
+				continue
+			}
+			entry := networkACLEntry(ruleAttrs, "rule_number", "rule_action", awssdk.ToBool(ruleAttrs.BoolPtr("egress")))
+			if !slices.ContainsFunc(acl.Entries, func(e aws2.NetworkAclEntry) bool {
+				return *e.Egress == *entry.Egress && awssdk.ToInt32(e.RuleNumber) == awssdk.ToInt32(entry.RuleNumber)
+			}) {
+				acl.Entries = append(acl.Entries, entry)
+			}
+		}
+		// the rules denying all traffic not matched by other rules are added by AWS, and cannot be changed
+		for _, egress := range []bool{true, false} {
+			acl.Entries = append(acl.Entries, aws2.NetworkAclEntry{RuleNumber: awssdk.Int32(implicitDenyRuleNumber),
+				RuleAction: aws2.RuleActionDeny, Protocol: awssdk.String(allProtocols), CidrBlock: awssdk.String(anyIPv4),
+				Egress: awssdk.Bool(egress)})
+		}
+		slices.SortStableFunc(acl.Entries, func(a, b aws2.NetworkAclEntry) int {
+			if *a.Egress != *b.Egress {
+				return cmp.Compare(boolToInt(*b.Egress), boolToInt(*a.Egress))
+			}
+			return cmp.Compare(awssdk.ToInt32(a.RuleNumber), awssdk.ToInt32(b.RuleNumber))
+		})
+		res = append(res, &NetworkACL{NetworkAcl: acl, ResourceLocation: location(attrs)})
+	}
+	return res
+}
+
+// networkACLEntry translates an ingress/egress block of an ACL, or an ACL rule resource, which use different key names
+func networkACLEntry(attrs tfstate.Attributes, ruleNumberKey, actionKey string, egress bool) aws2.NetworkAclEntry {
+	protocol := attrs.String("protocol")
+	if number, ok := protocolNumbers[strings.ToLower(protocol)]; ok {
+		protocol = number
+	}
+	entry := aws2.NetworkAclEntry{
+		RuleNumber:    attrs.Int32Ptr(ruleNumberKey),
+		RuleAction:    aws2.RuleAction(strings.ToLower(attrs.String(actionKey))),
+		Protocol:      &protocol,
+		CidrBlock:     attrs.StringPtr("cidr_block"),
+		Ipv6CidrBlock: attrs.StringPtr("ipv6_cidr_block"),
+		Egress:        &egress,
+	}
+	switch protocol {
+	case protocolNumbers["tcp"], protocolNumbers["udp"]:
+		entry.PortRange = &aws2.PortRange{From: attrs.Int32Ptr("from_port"), To: attrs.Int32Ptr("to_port")}
+	case protocolNumbers["icmp"], protocolNumbers["icmpv6"]:
+		entry.IcmpTypeCode = &aws2.IcmpTypeCode{Type: attrs.Int32Ptr("icmp_type"), Code: attrs.Int32Ptr("icmp_code")}
+	}
+	return entry
+}
+
+func (t *tfTranslator) securityGroups() []*SecurityGroup {
+	res := []*SecurityGroup{}
+	for _, attrs := range t.resources(tfSecurityGroup, tfDefaultSecurityGroup) {
+		sg := aws2.SecurityGroup{
+			GroupId:             attrs.StringPtr("id"),
+			GroupName:           attrs.StringPtr("name"),
+			Description:         attrs.StringPtr("description"),
+			SecurityGroupArn:    attrs.StringPtr("arn"),
+			VpcId:               attrs.StringPtr("vpc_id"),
+			OwnerId:             attrs.StringPtr("owner_id"),
+			IpPermissions:       []aws2.IpPermission{},
+			IpPermissionsEgress: []aws2.IpPermission{},
+			Tags:                tags(attrs),
+		}
+		for _, ruleAttrs := range attrs.Blocks("ingress") {
+			sg.IpPermissions = append(sg.IpPermissions, t.ipPermission(ruleAttrs, &sg))
+		}
+		for _, ruleAttrs := range attrs.Blocks("egress") {
+			sg.IpPermissionsEgress = append(sg.IpPermissionsEgress, t.ipPermission(ruleAttrs, &sg))
+		}
+		t.addSecurityGroupRules(&sg)
+		res = append(res, &SecurityGroup{SecurityGroup: sg, ResourceLocation: location(attrs)})
+	}
+	return res
+}
+
+// addSecurityGroupRules adds the rules of a security group that are managed as separate resources.
+// After a refresh, such rules may also appear in the ingress and egress blocks of the group, so covered rules are skipped
+func (t *tfTranslator) addSecurityGroupRules(sg *aws2.SecurityGroup) {
+	addRule := func(permissions *[]aws2.IpPermission, permission aws2.IpPermission) {
+		if !slices.ContainsFunc(*permissions, func(p aws2.IpPermission) bool { return permissionCovers(&p, &permission) }) {
+			*permissions = append(*permissions, permission)
+		}
+	}
+
+	for _, ruleAttrs := range t.state.Resources(tfSecurityGroupRule) {
+		if ruleAttrs.String("security_group_id") != awssdk.ToString(sg.GroupId) {
+			continue
+		}
+		permission := t.ipPermission(ruleAttrs, sg)
+		if sourceGroup := ruleAttrs.String("source_security_group_id"); sourceGroup != "" {
+			permission.UserIdGroupPairs = append(permission.UserIdGroupPairs, t.groupPair(sourceGroup, sg))
+		}
+		if ruleAttrs.String("type") == "egress" {
+			addRule(&sg.IpPermissionsEgress, permission)
+		} else {
+			addRule(&sg.IpPermissions, permission)
+		}
+	}
+
+	for _, ruleType := range []string{tfSecurityGroupIngressRule, tfSecurityGroupEgressRule} {
+		for _, ruleAttrs := range t.state.Resources(ruleType) {
+			if ruleAttrs.String("security_group_id") != awssdk.ToString(sg.GroupId) {
+				continue
+			}
+			permission := t.vpcSecurityGroupRulePermission(ruleAttrs, sg)
+			if ruleType == tfSecurityGroupEgressRule {
+				addRule(&sg.IpPermissionsEgress, permission)
+			} else {
+				addRule(&sg.IpPermissions, permission)
+			}
+		}
+	}
+}
+
+// ipPermission translates an ingress/egress block of a security group, or an aws_security_group_rule resource
+func (t *tfTranslator) ipPermission(attrs tfstate.Attributes, sg *aws2.SecurityGroup) aws2.IpPermission {
+	permission := newIPPermission(attrs.String("protocol"), attrs.Int32Ptr("from_port"), attrs.Int32Ptr("to_port"))
+	description := attrs.StringPtr("description")
+	for _, cidr := range attrs.Strings("cidr_blocks") {
+		permission.IpRanges = append(permission.IpRanges, aws2.IpRange{CidrIp: awssdk.String(cidr), Description: description})
+	}
+	for _, cidr := range attrs.Strings("ipv6_cidr_blocks") {
+		permission.Ipv6Ranges = append(permission.Ipv6Ranges, aws2.Ipv6Range{CidrIpv6: awssdk.String(cidr), Description: description})
+	}
+	for _, prefixList := range attrs.Strings("prefix_list_ids") {
+		permission.PrefixListIds = append(permission.PrefixListIds,
+			aws2.PrefixListId{PrefixListId: awssdk.String(prefixList), Description: description})
+	}
+	for _, group := range attrs.Strings("security_groups") {
+		permission.UserIdGroupPairs = append(permission.UserIdGroupPairs, t.groupPair(group, sg))
+	}
+	if self := attrs.BoolPtr("self"); self != nil && *self {
+		permission.UserIdGroupPairs = append(permission.UserIdGroupPairs, t.groupPair(awssdk.ToString(sg.GroupId), sg))
+	}
+	return permission
+}
+
+// vpcSecurityGroupRulePermission translates an aws_vpc_security_group_ingress_rule or egress rule, each having a single remote
+func (t *tfTranslator) vpcSecurityGroupRulePermission(attrs tfstate.Attributes, sg *aws2.SecurityGroup) aws2.IpPermission {
+	permission := newIPPermission(attrs.String("ip_protocol"), attrs.Int32Ptr("from_port"), attrs.Int32Ptr("to_port"))
+	description := attrs.StringPtr("description")
+	switch {
+	case attrs.String("cidr_ipv4") != "":
+		permission.IpRanges = append(permission.IpRanges, aws2.IpRange{CidrIp: attrs.StringPtr("cidr_ipv4"), Description: description})
+	case attrs.String("cidr_ipv6") != "":
+		permission.Ipv6Ranges = append(permission.Ipv6Ranges, aws2.Ipv6Range{CidrIpv6: attrs.StringPtr("cidr_ipv6"), Description: description})
+	case attrs.String("prefix_list_id") != "":
+		permission.PrefixListIds = append(permission.PrefixListIds,
+			aws2.PrefixListId{PrefixListId: attrs.StringPtr("prefix_list_id"), Description: description})
+	case attrs.String("referenced_security_group_id") != "":
+		pair := t.groupPair(attrs.String("referenced_security_group_id"), sg)
+		pair.Description = description
+		permission.UserIdGroupPairs = append(permission.UserIdGroupPairs, pair)
+	}
+	return permission
+}
+
+// newIPPermission creates a permission without remotes, normalizing the protocol as returned by the AWS API
+// (by name for TCP, UDP and ICMP). As in the API, ports are omitted from permissions for all protocols
+func newIPPermission(protocol string, fromPort, toPort *int32) aws2.IpPermission {
+	protocol = strings.ToLower(protocol)
+	for name, number := range protocolNumbers {
+		if protocol == number || protocol == name {
+			protocol = name
+		}
+	}
+	if protocol == "all" {
+		protocol = allProtocols
+		fromPort, toPort = nil, nil
+	}
+	return aws2.IpPermission{IpProtocol: &protocol, FromPort: fromPort, ToPort: toPort, IpRanges: []aws2.IpRange{},
+		Ipv6Ranges: []aws2.Ipv6Range{}, PrefixListIds: []aws2.PrefixListId{}, UserIdGroupPairs: []aws2.UserIdGroupPair{}}
+}
+
+// groupPair references a security group (given as an ID, or as "<account>/<id>"), in the account and VPC of sg
+func (t *tfTranslator) groupPair(group string, sg *aws2.SecurityGroup) aws2.UserIdGroupPair {
+	userID := sg.OwnerId
+	if account, id, found := strings.Cut(group, "/"); found {
+		userID, group = &account, id
+	}
+	pair := aws2.UserIdGroupPair{GroupId: awssdk.String(group), UserId: userID, VpcId: sg.VpcId}
+	if name, ok := t.sgNames[group]; ok {
+		pair.GroupName = &name
+	}
+	return pair
+}
+
+// permissionCovers returns true if permission p allows all the traffic allowed by permission q
+func permissionCovers(p, q *aws2.IpPermission) bool {
+	if awssdk.ToString(p.IpProtocol) != awssdk.ToString(q.IpProtocol) || awssdk.ToInt32(p.FromPort) != awssdk.ToInt32(q.FromPort) ||
+		awssdk.ToInt32(p.ToPort) != awssdk.ToInt32(q.ToPort) {
+		return false
+	}
+	return containsAll(p.IpRanges, q.IpRanges, func(r aws2.IpRange) string { return awssdk.ToString(r.CidrIp) }) &&
+		containsAll(p.Ipv6Ranges, q.Ipv6Ranges, func(r aws2.Ipv6Range) string { return awssdk.ToString(r.CidrIpv6) }) &&
+		containsAll(p.PrefixListIds, q.PrefixListIds, func(r aws2.PrefixListId) string { return awssdk.ToString(r.PrefixListId) }) &&
+		containsAll(p.UserIdGroupPairs, q.UserIdGroupPairs, func(r aws2.UserIdGroupPair) string { return awssdk.ToString(r.GroupId) })
+}
+
+func containsAll[T any](items, subItems []T, key func(T) string) bool {
+	for _, subItem := range subItems {
+		if !slices.ContainsFunc(items, func(item T) bool { return key(item) == key(subItem) }) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *tfTranslator) instances() []*Instance {
+	res := []*Instance{}
+	for _, attrs := range t.state.Resources(tfInstance) {
+		groups := []aws2.GroupIdentifier{}
+		for _, groupID := range attrs.Strings("vpc_security_group_ids") {
+			group := aws2.GroupIdentifier{GroupId: awssdk.String(groupID)}
+			if name, ok := t.sgNames[groupID]; ok {
+				group.GroupName = &name
+			}
+			groups = append(groups, group)
+		}
+		var vpcID *string
+		if subnetVPC, ok := t.subnetVPCs[attrs.String("subnet_id")]; ok {
+			vpcID = awssdk.String(subnetVPC)
+		}
+		instance := aws2.Instance{
+			InstanceId:       attrs.StringPtr("id"),
+			ImageId:          attrs.StringPtr("ami"),
+			InstanceType:     aws2.InstanceType(attrs.String("instance_type")),
+			SubnetId:         attrs.StringPtr("subnet_id"),
+			VpcId:            vpcID,
+			PrivateIpAddress: attrs.StringPtr("private_ip"),
+			PublicIpAddress:  attrs.StringPtr("public_ip"),
+			Placement:        &aws2.Placement{AvailabilityZone: attrs.StringPtr("availability_zone")},
+			State:            &aws2.InstanceState{Name: aws2.InstanceStateName(attrs.String("instance_state"))},
+			SecurityGroups:   groups,
+			Tags:             tags(attrs),
+			NetworkInterfaces: []aws2.InstanceNetworkInterface{{
+				NetworkInterfaceId: attrs.StringPtr("primary_network_interface_id"),
+				SubnetId:           attrs.StringPtr("subnet_id"),
+				VpcId:              vpcID,
+				PrivateIpAddress:   attrs.StringPtr("private_ip"),
+				PrivateIpAddresses: []aws2.InstancePrivateIpAddress{{Primary: awssdk.Bool(true), PrivateIpAddress: attrs.StringPtr("private_ip")}},
+				Groups:             groups,
+				Attachment:         &aws2.InstanceNetworkInterfaceAttachment{DeviceIndex: awssdk.Int32(0)},
+			}},
+		}
+		res = append(res, &Instance{Instance: instance, ResourceLocation: location(attrs)})
+	}
+	return res
+}
+
+// location returns the account and the region of a resource, based on its ARN (or on its owner, if it has no ARN)
+func location(attrs tfstate.Attributes) ResourceLocation {
+	resourceARN, err := arn.Parse(attrs.String("arn"))
+	if err != nil {
+		return ResourceLocation{AccountID: attrs.String("owner_id")}
+	}
+	return ResourceLocation{AccountID: resourceARN.AccountID, Region: resourceARN.Region}
+}
+
+// tags translates the tags of a resource, sorted by key
+func tags(attrs tfstate.Attributes) []aws2.Tag {
+	tagsMap := attrs.StringMap("tags")
+	res := make([]aws2.Tag, 0, len(tagsMap))
+	for key, value := range tagsMap {
+		res = append(res, aws2.Tag{Key: awssdk.String(key), Value: awssdk.String(value)})
+	}
+	slices.SortFunc(res, func(a, b aws2.Tag) int { return cmp.Compare(*a.Key, *b.Key) })
+	return res
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
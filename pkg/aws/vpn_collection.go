@@ -0,0 +1,351 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	aws2 "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const redacted = "<redacted>"
+
+// VpnGateway is a virtual private gateway. It is attached to VPCs through its VpcAttachments.
+// RouteTableIds lists the route tables that have a route to this gateway or that propagate its routes
+type VpnGateway struct {
+	aws2.VpnGateway
+	RouteTableIds []string
+	ResourceLocation
+}
+
+// CustomerGateway is the on-premises side of a Site-to-Site VPN connection.
+// VpcIds and RouteTableIds are those of the VPN connections using this customer gateway
+type CustomerGateway struct {
+	aws2.CustomerGateway
+	VpcIds        []string
+	RouteTableIds []string
+	ResourceLocation
+}
+
+// VpnConnection is a Site-to-Site VPN connection, including its static routes (under Routes).
+// Tunnel pre-shared keys and the customer gateway configuration are redacted.
+// VpcIds and RouteTableIds are those of the gateway terminating the connection: for a virtual private gateway, its
+// attached VPCs and the route tables routing to it or propagating its routes; for a transit gateway, the VPCs attached
+// to it and the route tables routing to it. Both are empty for a connection terminating on a Cloud WAN core network
+type VpnConnection struct {
+	aws2.VpnConnection
+	VpcIds        []string
+	RouteTableIds []string
+	ResourceLocation
+}
+
+// ClientVpnEndpoint is a Client VPN endpoint, together with its target networks, authorization rules and routes.
+// Its VPC is VpcId; RouteTableIds are the route tables of the subnets of its target networks
+type ClientVpnEndpoint struct {
+	aws2.ClientVpnEndpoint
+	TargetNetworks     []aws2.TargetNetwork
+	AuthorizationRules []aws2.AuthorizationRule
+	Routes             []aws2.ClientVpnRoute
+	RouteTableIds      []string
+	ResourceLocation
+}
+
+// collectVpnResources collects all VPN-related resources of a single region
+func (resources *ResourcesContainer) collectVpnResources(client *ec2.Client, loc ResourceLocation) error {
+	routeTables, err := getRouteTables(client)
+	if err != nil {
+		return err
+	}
+
+	vpnGateways, err := getVpnGateways(client, loc, routeTables)
+	if err != nil {
+		return err
+	}
+	resources.VpnGWList = append(resources.VpnGWList, vpnGateways...)
+
+	vpnConnections, err := getVpnConnections(client, loc, vpnGateways, routeTables)
+	if err != nil {
+		return err
+	}
+	resources.VpnConnectionsList = append(resources.VpnConnectionsList, vpnConnections...)
+
+	customerGateways, err := getCustomerGateways(client, loc, vpnConnections)
+	if err != nil {
+		return err
+	}
+	resources.CustomerGWList = append(resources.CustomerGWList, customerGateways...)
+
+	clientVpnEndpoints, err := getClientVpnEndpoints(client, loc, routeTables)
+	if err != nil {
+		return err
+	}
+	resources.ClientVpnEndpointsList = append(resources.ClientVpnEndpointsList, clientVpnEndpoints...)
+
+	return nil
+}
+
+// getRouteTables gets all route tables. These are only used to link VPN resources to the route tables they affect
+func getRouteTables(client *ec2.Client) ([]aws2.RouteTable, error) {
+	res := []aws2.RouteTable{}
+	paginator := ec2.NewDescribeRouteTablesPaginator(client, &ec2.DescribeRouteTablesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getRouteTables] error getting route tables: %w", err)
+		}
+		res = append(res, page.RouteTables...)
+	}
+	return res, nil
+}
+
+// routeTablesOfGateway returns the IDs of route tables that either propagate the routes of the given gateway,
+// or have a route whose target is the given gateway (a virtual private gateway or a transit gateway)
+func routeTablesOfGateway(gatewayID string, routeTables []aws2.RouteTable) []string {
+	res := []string{}
+	for i := range routeTables {
+		rt := &routeTables[i]
+		propagates := slices.ContainsFunc(rt.PropagatingVgws, func(vgw aws2.PropagatingVgw) bool {
+			return vgw.GatewayId != nil && *vgw.GatewayId == gatewayID
+		})
+		routesTo := slices.ContainsFunc(rt.Routes, func(route aws2.Route) bool {
+			return awssdk.ToString(route.GatewayId) == gatewayID || awssdk.ToString(route.TransitGatewayId) == gatewayID
+		})
+		if propagates || routesTo {
+			res = append(res, *rt.RouteTableId)
+		}
+	}
+	return res
+}
+
+// routeTableOfSubnet returns the ID of the route table explicitly associated with the given subnet,
+// or otherwise the ID of the main route table of its VPC
+func routeTableOfSubnet(subnetID, vpcID string, routeTables []aws2.RouteTable) string {
+	mainRouteTable := ""
+	for i := range routeTables {
+		rt := &routeTables[i]
+		for j := range rt.Associations {
+			association := &rt.Associations[j]
+			if awssdk.ToString(association.SubnetId) == subnetID {
+				return *rt.RouteTableId
+			}
+			if awssdk.ToBool(association.Main) && awssdk.ToString(rt.VpcId) == vpcID {
+				mainRouteTable = *rt.RouteTableId
+			}
+		}
+	}
+	return mainRouteTable
+}
+
+func getVpnGateways(client *ec2.Client, loc ResourceLocation, routeTables []aws2.RouteTable) ([]*VpnGateway, error) {
+	vpnGatewaysFromAPI, err := client.DescribeVpnGateways(context.TODO(), &ec2.DescribeVpnGatewaysInput{})
+	if err != nil {
+		return nil, fmt.Errorf("[getVpnGateways] error getting VPN gateways: %w", err)
+	}
+	res := make([]*VpnGateway, len(vpnGatewaysFromAPI.VpnGateways))
+	for i := range vpnGatewaysFromAPI.VpnGateways {
+		vgw := vpnGatewaysFromAPI.VpnGateways[i]
+		res[i] = &VpnGateway{VpnGateway: vgw, RouteTableIds: routeTablesOfGateway(*vgw.VpnGatewayId, routeTables), ResourceLocation: loc}
+	}
+	return res, nil
+}
+
+func getCustomerGateways(client *ec2.Client, loc ResourceLocation, vpnConnections []*VpnConnection) ([]*CustomerGateway, error) {
+	customerGatewaysFromAPI, err := client.DescribeCustomerGateways(context.TODO(), &ec2.DescribeCustomerGatewaysInput{})
+	if err != nil {
+		return nil, fmt.Errorf("[getCustomerGateways] error getting customer gateways: %w", err)
+	}
+	res := make([]*CustomerGateway, len(customerGatewaysFromAPI.CustomerGateways))
+	for i := range customerGatewaysFromAPI.CustomerGateways {
+		customerGateway := &CustomerGateway{
+			CustomerGateway:  customerGatewaysFromAPI.CustomerGateways[i],
+			VpcIds:           []string{},
+			RouteTableIds:    []string{},
+			ResourceLocation: loc,
+		}
+		for _, connection := range vpnConnections {
+			if awssdk.ToString(connection.CustomerGatewayId) != *customerGateway.CustomerGatewayId {
+				continue
+			}
+			customerGateway.VpcIds = appendMissing(customerGateway.VpcIds, connection.VpcIds...)
+			customerGateway.RouteTableIds = appendMissing(customerGateway.RouteTableIds, connection.RouteTableIds...)
+		}
+		res[i] = customerGateway
+	}
+	return res, nil
+}
+
+// appendMissing appends to a slice the given values that it does not already contain
+func appendMissing(slice []string, values ...string) []string {
+	for _, val := range values {
+		if !slices.Contains(slice, val) {
+			slice = append(slice, val)
+		}
+	}
+	return slice
+}
+
+func getVpnConnections(client *ec2.Client, loc ResourceLocation, vpnGateways []*VpnGateway,
+	routeTables []aws2.RouteTable) ([]*VpnConnection, error) {
+	vpnConnectionsFromAPI, err := client.DescribeVpnConnections(context.TODO(), &ec2.DescribeVpnConnectionsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("[getVpnConnections] error getting VPN connections: %w", err)
+	}
+	transitGatewayIDs := []string{}
+	for i := range vpnConnectionsFromAPI.VpnConnections {
+		if tgwID := vpnConnectionsFromAPI.VpnConnections[i].TransitGatewayId; tgwID != nil {
+			transitGatewayIDs = appendMissing(transitGatewayIDs, *tgwID)
+		}
+	}
+	transitGatewayVpcs, err := getTransitGatewayVpcs(client, transitGatewayIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*VpnConnection, len(vpnConnectionsFromAPI.VpnConnections))
+	for i := range vpnConnectionsFromAPI.VpnConnections {
+		connection := &VpnConnection{
+			VpnConnection:    vpnConnectionsFromAPI.VpnConnections[i],
+			VpcIds:           []string{},
+			RouteTableIds:    []string{},
+			ResourceLocation: loc,
+		}
+		redactVpnConnectionSecrets(&connection.VpnConnection)
+		switch {
+		case connection.VpnGatewayId != nil:
+			for _, vgw := range vpnGateways {
+				if *connection.VpnGatewayId != *vgw.VpnGatewayId {
+					continue
+				}
+				for j := range vgw.VpcAttachments {
+					if vgw.VpcAttachments[j].State == aws2.AttachmentStatusAttached { // skip detached (and detaching) VPCs
+						connection.VpcIds = append(connection.VpcIds, *vgw.VpcAttachments[j].VpcId)
+					}
+				}
+				connection.RouteTableIds = append(connection.RouteTableIds, vgw.RouteTableIds...)
+			}
+		case connection.TransitGatewayId != nil:
+			connection.VpcIds = append(connection.VpcIds, transitGatewayVpcs[*connection.TransitGatewayId]...)
+			connection.RouteTableIds = routeTablesOfGateway(*connection.TransitGatewayId, routeTables)
+		}
+		res[i] = connection
+	}
+	return res, nil
+}
+
+// getTransitGatewayVpcs returns the IDs of the VPCs attached to each of the given transit gateways
+func getTransitGatewayVpcs(client *ec2.Client, transitGatewayIDs []string) (map[string][]string, error) {
+	res := map[string][]string{}
+	if len(transitGatewayIDs) == 0 {
+		return res, nil
+	}
+	paginator := ec2.NewDescribeTransitGatewayAttachmentsPaginator(client, &ec2.DescribeTransitGatewayAttachmentsInput{
+		Filters: []aws2.Filter{
+			{Name: awssdk.String("transit-gateway-id"), Values: transitGatewayIDs},
+			{Name: awssdk.String("resource-type"), Values: []string{string(aws2.TransitGatewayAttachmentResourceTypeVpc)}},
+			{Name: awssdk.String("state"), Values: []string{string(aws2.TransitGatewayAttachmentStateAvailable)}},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getTransitGatewayVpcs] error getting transit gateway attachments: %w", err)
+		}
+		for i := range page.TransitGatewayAttachments {
+			attachment := &page.TransitGatewayAttachments[i]
+			tgwID := *attachment.TransitGatewayId
+			res[tgwID] = appendMissing(res[tgwID], *attachment.ResourceId)
+		}
+	}
+	return res, nil
+}
+
+// redactVpnConnectionSecrets removes the tunnels' pre-shared keys, which also appear in the customer gateway configuration
+func redactVpnConnectionSecrets(connection *aws2.VpnConnection) {
+	connection.CustomerGatewayConfiguration = nil
+	if connection.Options == nil {
+		return
+	}
+	for i := range connection.Options.TunnelOptions {
+		if connection.Options.TunnelOptions[i].PreSharedKey != nil {
+			psk := redacted
+			connection.Options.TunnelOptions[i].PreSharedKey = &psk
+		}
+	}
+}
+
+// getClientVpnEndpoints gets all Client VPN endpoints, with their target networks, authorization rules and routes
+func getClientVpnEndpoints(client *ec2.Client, loc ResourceLocation, routeTables []aws2.RouteTable) ([]*ClientVpnEndpoint, error) {
+	res := []*ClientVpnEndpoint{}
+	paginator := ec2.NewDescribeClientVpnEndpointsPaginator(client, &ec2.DescribeClientVpnEndpointsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getClientVpnEndpoints] error getting Client VPN endpoints: %w", err)
+		}
+		for i := range page.ClientVpnEndpoints {
+			endpoint, err := getClientVpnEndpointDetails(client, &page.ClientVpnEndpoints[i], loc)
+			if err != nil {
+				return nil, err
+			}
+			for _, network := range endpoint.TargetNetworks {
+				routeTable := routeTableOfSubnet(awssdk.ToString(network.TargetNetworkId), awssdk.ToString(network.VpcId), routeTables)
+				if routeTable != "" {
+					endpoint.RouteTableIds = appendMissing(endpoint.RouteTableIds, routeTable)
+				}
+			}
+			res = append(res, endpoint)
+		}
+	}
+	return res, nil
+}
+
+func getClientVpnEndpointDetails(client *ec2.Client, endpoint *aws2.ClientVpnEndpoint, loc ResourceLocation) (*ClientVpnEndpoint, error) {
+	res := &ClientVpnEndpoint{
+		ClientVpnEndpoint:  *endpoint,
+		TargetNetworks:     []aws2.TargetNetwork{},
+		AuthorizationRules: []aws2.AuthorizationRule{},
+		Routes:             []aws2.ClientVpnRoute{},
+		RouteTableIds:      []string{},
+		ResourceLocation:   loc,
+	}
+	endpointID := endpoint.ClientVpnEndpointId
+
+	networksPaginator := ec2.NewDescribeClientVpnTargetNetworksPaginator(client,
+		&ec2.DescribeClientVpnTargetNetworksInput{ClientVpnEndpointId: endpointID})
+	for networksPaginator.HasMorePages() {
+		page, err := networksPaginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getClientVpnEndpointDetails] error getting target networks for %s: %w", *endpointID, err)
+		}
+		res.TargetNetworks = append(res.TargetNetworks, page.ClientVpnTargetNetworks...)
+	}
+
+	rulesPaginator := ec2.NewDescribeClientVpnAuthorizationRulesPaginator(client,
+		&ec2.DescribeClientVpnAuthorizationRulesInput{ClientVpnEndpointId: endpointID})
+	for rulesPaginator.HasMorePages() {
+		page, err := rulesPaginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getClientVpnEndpointDetails] error getting authorization rules for %s: %w", *endpointID, err)
+		}
+		res.AuthorizationRules = append(res.AuthorizationRules, page.AuthorizationRules...)
+	}
+
+	routesPaginator := ec2.NewDescribeClientVpnRoutesPaginator(client, &ec2.DescribeClientVpnRoutesInput{ClientVpnEndpointId: endpointID})
+	for routesPaginator.HasMorePages() {
+		page, err := routesPaginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[getClientVpnEndpointDetails] error getting routes for %s: %w", *endpointID, err)
+		}
+		res.Routes = append(res.Routes, page.Routes...)
+	}
+
+	return res, nil
+}
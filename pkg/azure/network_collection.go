@@ -0,0 +1,353 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v6"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v6"
+)
+
+// subscriptionCollector holds the clients for collecting the resources of a single subscription.
+// If resourceGroup is set, only resources of this resource group are collected
+type subscriptionCollector struct {
+	network       *armnetwork.ClientFactory
+	compute       *armcompute.ClientFactory
+	resourceGroup string
+}
+
+// collectPages is a generic function for collecting all items from all pages of an Azure pager.
+// R is the type of a page, T is the type of the items in the page
+func collectPages[R, T any](pager *runtime.Pager[R], getItems func(R) []*T) ([]*T, error) {
+	res := []*T{}
+	for pager.More() {
+		page, err := pager.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("[collectPages] error getting page: %w", err)
+		}
+		res = append(res, getItems(page)...)
+	}
+	return res, nil
+}
+
+// filterByLocation returns the items whose location is one of the given regions (all items if no regions are given)
+func filterByLocation[T any](items []*T, location func(*T) *string, regions []string) []*T {
+	if len(regions) == 0 {
+		return items
+	}
+	res := []*T{}
+	for _, item := range items {
+		for _, region := range regions {
+			if loc := location(item); loc != nil && strings.EqualFold(*loc, region) {
+				res = append(res, item)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// collectNetworkResources uses the network API to collect all network resources of a single subscription
+//
+//nolint:funlen // because Golang forces me to replicate code per-resource-type
+func (resources *ResourcesContainer) collectNetworkResources(collector *subscriptionCollector) error {
+	vnets, err := collector.getVirtualNetworks()
+	if err != nil {
+		return err
+	}
+	vnets = filterByLocation(vnets, func(vnet *armnetwork.VirtualNetwork) *string { return vnet.Location }, resources.regions)
+	resources.VirtualNetworksList = append(resources.VirtualNetworksList, vnets...)
+	for _, vnet := range vnets {
+		if vnet.Properties == nil {
+			continue
+		}
+		resources.SubnetsList = append(resources.SubnetsList, vnet.Properties.Subnets...)
+		resources.VNetPeeringsList = append(resources.VNetPeeringsList, vnet.Properties.VirtualNetworkPeerings...)
+	}
+
+	nics, err := collector.getNetworkInterfaces()
+	if err != nil {
+		return err
+	}
+	nics = filterByLocation(nics, func(nic *armnetwork.Interface) *string { return nic.Location }, resources.regions)
+	resources.NetworkInterfaceList = append(resources.NetworkInterfaceList, nics...)
+
+	nsgs, err := collector.getNetworkSecurityGroups(nics)
+	if err != nil {
+		return err
+	}
+	resources.NSGList = append(resources.NSGList,
+		filterByLocation(nsgs, func(nsg *NetworkSecurityGroup) *string { return nsg.SecurityGroup.Location }, resources.regions)...)
+
+	routeTables, err := collector.getRouteTables()
+	if err != nil {
+		return err
+	}
+	resources.RouteTablesList = append(resources.RouteTablesList,
+		filterByLocation(routeTables, func(rt *armnetwork.RouteTable) *string { return rt.Location }, resources.regions)...)
+
+	publicIPs, err := collector.getPublicIPAddresses()
+	if err != nil {
+		return err
+	}
+	resources.PublicIPList = append(resources.PublicIPList,
+		filterByLocation(publicIPs, func(ip *armnetwork.PublicIPAddress) *string { return ip.Location }, resources.regions)...)
+
+	natGWs, err := collector.getNatGateways()
+	if err != nil {
+		return err
+	}
+	resources.NatGWList = append(resources.NatGWList,
+		filterByLocation(natGWs, func(gw *armnetwork.NatGateway) *string { return gw.Location }, resources.regions)...)
+
+	privateEndpoints, err := collector.getPrivateEndpoints()
+	if err != nil {
+		return err
+	}
+	resources.PrivateEndpointsList = append(resources.PrivateEndpointsList,
+		filterByLocation(privateEndpoints, func(pe *armnetwork.PrivateEndpoint) *string { return pe.Location }, resources.regions)...)
+
+	firewalls, err := collector.getFirewalls()
+	if err != nil {
+		return err
+	}
+	resources.FirewallsList = append(resources.FirewallsList,
+		filterByLocation(firewalls, func(fw *armnetwork.AzureFirewall) *string { return fw.Location }, resources.regions)...)
+
+	return nil
+}
+
+func (collector *subscriptionCollector) getVirtualNetworks() ([]*armnetwork.VirtualNetwork, error) {
+	client := collector.network.NewVirtualNetworksClient()
+	var res []*armnetwork.VirtualNetwork
+	var err error
+	if collector.resourceGroup != "" {
+		res, err = collectPages(client.NewListPager(collector.resourceGroup, nil),
+			func(page armnetwork.VirtualNetworksClientListResponse) []*armnetwork.VirtualNetwork {
+				return page.Value
+			})
+	} else {
+		res, err = collectPages(client.NewListAllPager(nil),
+			func(page armnetwork.VirtualNetworksClientListAllResponse) []*armnetwork.VirtualNetwork {
+				return page.Value
+			})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[getVirtualNetworks] error getting virtual networks: %w", err)
+	}
+	return res, nil
+}
+
+func (collector *subscriptionCollector) getNetworkInterfaces() ([]*armnetwork.Interface, error) {
+	client := collector.network.NewInterfacesClient()
+	var res []*armnetwork.Interface
+	var err error
+	if collector.resourceGroup != "" {
+		res, err = collectPages(client.NewListPager(collector.resourceGroup, nil),
+			func(page armnetwork.InterfacesClientListResponse) []*armnetwork.Interface { return page.Value })
+	} else {
+		res, err = collectPages(client.NewListAllPager(nil),
+			func(page armnetwork.InterfacesClientListAllResponse) []*armnetwork.Interface { return page.Value })
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[getNetworkInterfaces] error getting network interfaces: %w", err)
+	}
+	return res, nil
+}
+
+// getNetworkSecurityGroups gets all network security groups, with the effective rules of the groups applied to the given
+// network interfaces
+func (collector *subscriptionCollector) getNetworkSecurityGroups(nics []*armnetwork.Interface) ([]*NetworkSecurityGroup, error) {
+	client := collector.network.NewSecurityGroupsClient()
+	var nsgs []*armnetwork.SecurityGroup
+	var err error
+	if collector.resourceGroup != "" {
+		nsgs, err = collectPages(client.NewListPager(collector.resourceGroup, nil),
+			func(page armnetwork.SecurityGroupsClientListResponse) []*armnetwork.SecurityGroup { return page.Value })
+	} else {
+		nsgs, err = collectPages(client.NewListAllPager(nil),
+			func(page armnetwork.SecurityGroupsClientListAllResponse) []*armnetwork.SecurityGroup {
+				return page.Value
+			})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[getNetworkSecurityGroups] error getting network security groups: %w", err)
+	}
+
+	effectiveRules := collector.getEffectiveSecurityRules(nics)
+	res := make([]*NetworkSecurityGroup, len(nsgs))
+	for i, nsg := range nsgs {
+		res[i] = &NetworkSecurityGroup{SecurityGroup: nsg, EffectiveRules: effectiveRules[strings.ToLower(*nsg.ID)]}
+		if res[i].EffectiveRules == nil {
+			res[i].EffectiveRules = map[string][]*armnetwork.EffectiveNetworkSecurityRule{}
+		}
+	}
+	return res, nil
+}
+
+// getEffectiveSecurityRules gets the effective rules of the security groups applied to the given network interfaces,
+// mapped by the (lower-case) ID of the security group, and then by the ID of the network interface. Effective rules are
+// only available for network interfaces of running virtual machines; failing to get them for a network interface is
+// logged, but is not an error
+func (collector *subscriptionCollector) getEffectiveSecurityRules(
+	nics []*armnetwork.Interface) map[string]map[string][]*armnetwork.EffectiveNetworkSecurityRule {
+	client := collector.network.NewInterfacesClient()
+	res := map[string]map[string][]*armnetwork.EffectiveNetworkSecurityRule{}
+	for _, nic := range nics {
+		if nic.Properties == nil || nic.Properties.VirtualMachine == nil {
+			continue
+		}
+		nicID, err := arm.ParseResourceID(*nic.ID)
+		if err != nil {
+			log.Printf("Skipping effective security rules of network interface %s: %v\n", *nic.ID, err)
+			continue
+		}
+		poller, err := client.BeginListEffectiveNetworkSecurityGroups(context.TODO(), nicID.ResourceGroupName, nicID.Name, nil)
+		if err != nil {
+			log.Printf("Skipping effective security rules of network interface %s: %v\n", *nic.ID, err)
+			continue
+		}
+		effective, err := poller.PollUntilDone(context.TODO(), nil)
+		if err != nil {
+			log.Printf("Skipping effective security rules of network interface %s: %v\n", *nic.ID, err)
+			continue
+		}
+		for _, nsg := range effective.Value {
+			if nsg.NetworkSecurityGroup == nil || nsg.NetworkSecurityGroup.ID == nil {
+				continue
+			}
+			nsgID := strings.ToLower(*nsg.NetworkSecurityGroup.ID)
+			if res[nsgID] == nil {
+				res[nsgID] = map[string][]*armnetwork.EffectiveNetworkSecurityRule{}
+			}
+			res[nsgID][*nic.ID] = nsg.EffectiveSecurityRules
+		}
+	}
+	return res
+}
+
+func (collector *subscriptionCollector) getRouteTables() ([]*armnetwork.RouteTable, error) {
+	client := collector.network.NewRouteTablesClient()
+	var res []*armnetwork.RouteTable
+	var err error
+	if collector.resourceGroup != "" {
+		res, err = collectPages(client.NewListPager(collector.resourceGroup, nil),
+			func(page armnetwork.RouteTablesClientListResponse) []*armnetwork.RouteTable { return page.Value })
+	} else {
+		res, err = collectPages(client.NewListAllPager(nil),
+			func(page armnetwork.RouteTablesClientListAllResponse) []*armnetwork.RouteTable { return page.Value })
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[getRouteTables] error getting route tables: %w", err)
+	}
+	return res, nil
+}
+
+func (collector *subscriptionCollector) getPublicIPAddresses() ([]*armnetwork.PublicIPAddress, error) {
+	client := collector.network.NewPublicIPAddressesClient()
+	var res []*armnetwork.PublicIPAddress
+	var err error
+	if collector.resourceGroup != "" {
+		res, err = collectPages(client.NewListPager(collector.resourceGroup, nil),
+			func(page armnetwork.PublicIPAddressesClientListResponse) []*armnetwork.PublicIPAddress {
+				return page.Value
+			})
+	} else {
+		res, err = collectPages(client.NewListAllPager(nil),
+			func(page armnetwork.PublicIPAddressesClientListAllResponse) []*armnetwork.PublicIPAddress {
+				return page.Value
+			})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[getPublicIPAddresses] error getting public IP addresses: %w", err)
+	}
+	return res, nil
+}
+
+func (collector *subscriptionCollector) getNatGateways() ([]*armnetwork.NatGateway, error) {
+	client := collector.network.NewNatGatewaysClient()
+	var res []*armnetwork.NatGateway
+	var err error
+	if collector.resourceGroup != "" {
+		res, err = collectPages(client.NewListPager(collector.resourceGroup, nil),
+			func(page armnetwork.NatGatewaysClientListResponse) []*armnetwork.NatGateway { return page.Value })
+	} else {
+		res, err = collectPages(client.NewListAllPager(nil),
+			func(page armnetwork.NatGatewaysClientListAllResponse) []*armnetwork.NatGateway { return page.Value })
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[getNatGateways] error getting NAT gateways: %w", err)
+	}
+	return res, nil
+}
+
+func (collector *subscriptionCollector) getPrivateEndpoints() ([]*armnetwork.PrivateEndpoint, error) {
+	client := collector.network.NewPrivateEndpointsClient()
+	var res []*armnetwork.PrivateEndpoint
+	var err error
+	if collector.resourceGroup != "" {
+		res, err = collectPages(client.NewListPager(collector.resourceGroup, nil),
+			func(page armnetwork.PrivateEndpointsClientListResponse) []*armnetwork.PrivateEndpoint {
+				return page.Value
+			})
+	} else {
+		res, err = collectPages(client.NewListBySubscriptionPager(nil),
+			func(page armnetwork.PrivateEndpointsClientListBySubscriptionResponse) []*armnetwork.PrivateEndpoint {
+				return page.Value
+			})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[getPrivateEndpoints] error getting private endpoints: %w", err)
+	}
+	return res, nil
+}
+
+func (collector *subscriptionCollector) getFirewalls() ([]*armnetwork.AzureFirewall, error) {
+	client := collector.network.NewAzureFirewallsClient()
+	var res []*armnetwork.AzureFirewall
+	var err error
+	if collector.resourceGroup != "" {
+		res, err = collectPages(client.NewListPager(collector.resourceGroup, nil),
+			func(page armnetwork.AzureFirewallsClientListResponse) []*armnetwork.AzureFirewall { return page.Value })
+	} else {
+		res, err = collectPages(client.NewListAllPager(nil),
+			func(page armnetwork.AzureFirewallsClientListAllResponse) []*armnetwork.AzureFirewall {
+				return page.Value
+			})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[getFirewalls] error getting Azure firewalls: %w", err)
+	}
+	return res, nil
+}
+
+func (collector *subscriptionCollector) getVirtualMachines() ([]*armcompute.VirtualMachine, error) {
+	client := collector.compute.NewVirtualMachinesClient()
+	var res []*armcompute.VirtualMachine
+	var err error
+	if collector.resourceGroup != "" {
+		res, err = collectPages(client.NewListPager(collector.resourceGroup, nil),
+			func(page armcompute.VirtualMachinesClientListResponse) []*armcompute.VirtualMachine {
+				return page.Value
+			})
+	} else {
+		res, err = collectPages(client.NewListAllPager(nil),
+			func(page armcompute.VirtualMachinesClientListAllResponse) []*armcompute.VirtualMachine {
+				return page.Value
+			})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[getVirtualMachines] error getting virtual machines: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/common"
+)
+
+// RegisterProvider registers the Azure provider, together with its collect flags
+func RegisterProvider() error {
+	options := &CollectOptions{}
+	flags := pflag.NewFlagSet(string(common.Azure), pflag.ContinueOnError)
+	flags.StringArrayVar(&options.SubscriptionIDs, "azure-subscription", nil,
+		"Azure subscription id from which to collect resources (default: all enabled subscriptions)")
+
+	return common.RegisterProvider(&common.ProviderInfo{
+		Name: common.Azure,
+		NewResourcesContainer: func(regions []string, resourceGroup string) common.ResourcesContainerInf {
+			return NewResourcesContainer(regions, resourceGroup, options)
+		},
+		AllRegions:             func() []string { return azureRegions },
+		SupportsResourceGroups: true,
+		Flags:                  flags,
+	})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+// list of azure (public cloud) regions, based on "az account list-locations"
+var azureRegions = []string{
+	"eastus",
+	"eastus2",
+	"southcentralus",
+	"westus2",
+	"westus3",
+	"australiaeast",
+	"southeastasia",
+	"northeurope",
+	"swedencentral",
+	"uksouth",
+	"westeurope",
+	"centralus",
+	"southafricanorth",
+	"centralindia",
+	"eastasia",
+	"japaneast",
+	"koreacentral",
+	"canadacentral",
+	"francecentral",
+	"germanywestcentral",
+	"italynorth",
+	"norwayeast",
+	"polandcentral",
+	"spaincentral",
+	"switzerlandnorth",
+	"mexicocentral",
+	"uaenorth",
+	"brazilsouth",
+	"israelcentral",
+	"qatarcentral",
+	"northcentralus",
+	"westus",
+	"japanwest",
+	"westcentralus",
+	"southafricawest",
+	"australiacentral",
+	"australiacentral2",
+	"australiasoutheast",
+	"jioindiacentral",
+	"jioindiawest",
+	"koreasouth",
+	"southindia",
+	"westindia",
+	"canadaeast",
+	"francesouth",
+	"germanynorth",
+	"norwaywest",
+	"switzerlandwest",
+	"ukwest",
+	"uaecentral",
+	"brazilsoutheast",
+}
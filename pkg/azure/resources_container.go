@@ -0,0 +1,220 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v6"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v6"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armsubscriptions"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/common"
+	"github.com/np-guard/cloud-resource-collector/pkg/version"
+)
+
+// ResourcesContainer holds the results of collecting the configurations of all resources.
+// This includes: Azure firewalls, NAT gateways, network interfaces, network security groups, private endpoints,
+// public IP addresses, route tables, subnets, virtual machines, virtual networks and virtual network peerings
+type ResourcesContainer struct {
+	common.ResourceModelMetadata
+	FirewallsList        []*armnetwork.AzureFirewall         `json:"firewalls"`
+	NatGWList            []*armnetwork.NatGateway            `json:"nat_gateways"`
+	NetworkInterfaceList []*armnetwork.Interface             `json:"network_interfaces"`
+	NSGList              []*NetworkSecurityGroup             `json:"network_security_groups"`
+	PrivateEndpointsList []*armnetwork.PrivateEndpoint       `json:"private_endpoints"`
+	PublicIPList         []*armnetwork.PublicIPAddress       `json:"public_ip_addresses"`
+	RouteTablesList      []*armnetwork.RouteTable            `json:"route_tables"`
+	SubnetsList          []*armnetwork.Subnet                `json:"subnets"`
+	VirtualMachinesList  []*armcompute.VirtualMachine        `json:"virtual_machines"`
+	VirtualNetworksList  []*armnetwork.VirtualNetwork        `json:"virtual_networks"`
+	VNetPeeringsList     []*armnetwork.VirtualNetworkPeering `json:"vnet_peerings"`
+	regions              []string
+	resourceGroup        string
+	options              CollectOptions
+}
+
+// CollectOptions holds the Azure-specific options for collecting resources
+type CollectOptions struct {
+	// SubscriptionIDs are the subscriptions from which to collect resources. If empty, resources are collected
+	// from all enabled subscriptions of the credentials
+	SubscriptionIDs []string
+	// Credential overrides the default credential chain of azidentity (environment, workload identity, managed identity, Azure CLI)
+	Credential azcore.TokenCredential
+	// ClientOptions are passed to all Azure clients, e.g., for replaying recorded HTTP responses in tests
+	ClientOptions *arm.ClientOptions
+}
+
+// NetworkSecurityGroup is a network security group, together with its effective rules for each network interface it
+// applies to, by the ID of the network interface. Effective rules are the group's rules, with service tags and augmented
+// prefixes expanded into address prefixes (which may differ between network interfaces, e.g., for the VirtualNetwork
+// tag). They are only available for network interfaces of running virtual machines (directly or through their subnet)
+type NetworkSecurityGroup struct {
+	SecurityGroup  *armnetwork.SecurityGroup                             `json:"security_group"`
+	EffectiveRules map[string][]*armnetwork.EffectiveNetworkSecurityRule `json:"effective_rules"`
+}
+
+// NewResourcesContainer creates an empty resources container
+func NewResourcesContainer(regions []string, resourceGroup string, options *CollectOptions) *ResourcesContainer {
+	if options == nil {
+		options = &CollectOptions{}
+	}
+	return &ResourcesContainer{
+		FirewallsList:         []*armnetwork.AzureFirewall{},
+		NatGWList:             []*armnetwork.NatGateway{},
+		NetworkInterfaceList:  []*armnetwork.Interface{},
+		NSGList:               []*NetworkSecurityGroup{},
+		PrivateEndpointsList:  []*armnetwork.PrivateEndpoint{},
+		PublicIPList:          []*armnetwork.PublicIPAddress{},
+		RouteTablesList:       []*armnetwork.RouteTable{},
+		SubnetsList:           []*armnetwork.Subnet{},
+		VirtualMachinesList:   []*armcompute.VirtualMachine{},
+		VirtualNetworksList:   []*armnetwork.VirtualNetwork{},
+		VNetPeeringsList:      []*armnetwork.VirtualNetworkPeering{},
+		ResourceModelMetadata: common.ResourceModelMetadata{Version: version.VersionCore, Provider: string(common.Azure)},
+		regions:               regions,
+		resourceGroup:         resourceGroup,
+		options:               *options,
+	}
+}
+
+// PrintStats outputs the number of items of each type
+func (resources *ResourcesContainer) PrintStats() {
+	fmt.Printf("Found %d Azure firewalls\n", len(resources.FirewallsList))
+	fmt.Printf("Found %d NAT gateways\n", len(resources.NatGWList))
+	fmt.Printf("Found %d network interfaces\n", len(resources.NetworkInterfaceList))
+	fmt.Printf("Found %d network security groups\n", len(resources.NSGList))
+	fmt.Printf("Found %d private endpoints\n", len(resources.PrivateEndpointsList))
+	fmt.Printf("Found %d public IP addresses\n", len(resources.PublicIPList))
+	fmt.Printf("Found %d route tables\n", len(resources.RouteTablesList))
+	fmt.Printf("Found %d subnets\n", len(resources.SubnetsList))
+	fmt.Printf("Found %d virtual machines\n", len(resources.VirtualMachinesList))
+	fmt.Printf("Found %d virtual networks\n", len(resources.VirtualNetworksList))
+	fmt.Printf("Found %d virtual network peerings\n", len(resources.VNetPeeringsList))
+}
+
+// ToJSONString converts a ResourcesContainer into a json-formatted-string
+func (resources *ResourcesContainer) ToJSONString() (string, error) {
+	toPrint, err := json.MarshalIndent(resources, "", "    ")
+	return string(toPrint), err
+}
+
+func (resources *ResourcesContainer) AllRegions() []string {
+	return azureRegions
+}
+
+func (resources *ResourcesContainer) GetResources() common.ResourcesModel {
+	return resources
+}
+
+func (resources *ResourcesContainer) Fabricate(opts *common.FabricateOptions) {
+}
+
+// CollectResourcesFromAPI uses Azure APIs to collect resource configuration information
+func (resources *ResourcesContainer) CollectResourcesFromAPI() error {
+	credential := resources.options.Credential
+	if credential == nil {
+		var err error
+		credential, err = azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return fmt.Errorf("CollectResourcesFromAPI error getting Azure credentials: %w", err)
+		}
+	}
+
+	if requestedRegions := resources.regions; len(requestedRegions) > 0 {
+		resources.regions = knownRegions(requestedRegions)
+		if len(resources.regions) == 0 {
+			return fmt.Errorf("CollectResourcesFromAPI error: no known region in %s", strings.Join(requestedRegions, ", "))
+		}
+	}
+
+	subscriptionIDs := resources.options.SubscriptionIDs
+	if len(subscriptionIDs) == 0 {
+		var err error
+		subscriptionIDs, err = resources.getSubscriptionIDs(credential)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, subscriptionID := range subscriptionIDs {
+		log.Printf("Collecting resources from subscription %s\n", subscriptionID)
+		err := resources.collectSubscriptionResources(credential, subscriptionID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// knownRegions returns the given regions that are known Azure regions, skipping (and logging) unknown ones
+func knownRegions(regions []string) []string {
+	res := []string{}
+	for _, region := range regions {
+		if !slices.Contains(azureRegions, strings.ToLower(region)) {
+			log.Printf("Unknown region %s. Available regions for provider azure: %s\n", region, strings.Join(azureRegions, ", "))
+			continue
+		}
+		res = append(res, region)
+	}
+	return res
+}
+
+// getSubscriptionIDs returns the IDs of all enabled subscriptions accessible with the given credentials
+func (resources *ResourcesContainer) getSubscriptionIDs(credential azcore.TokenCredential) ([]string, error) {
+	client, err := armsubscriptions.NewClient(credential, resources.options.ClientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("CollectResourcesFromAPI error creating subscriptions client: %w", err)
+	}
+	subscriptions, err := collectPages(client.NewListPager(nil),
+		func(page armsubscriptions.ClientListResponse) []*armsubscriptions.Subscription { return page.Value })
+	if err != nil {
+		return nil, fmt.Errorf("CollectResourcesFromAPI error listing subscriptions: %w", err)
+	}
+
+	res := []string{}
+	for _, subscription := range subscriptions {
+		if subscription.State != nil && *subscription.State == armsubscriptions.SubscriptionStateEnabled {
+			res = append(res, *subscription.SubscriptionID)
+		}
+	}
+	return res, nil
+}
+
+// collectSubscriptionResources collects the resources of a single subscription
+func (resources *ResourcesContainer) collectSubscriptionResources(credential azcore.TokenCredential, subscriptionID string) error {
+	networkClients, err := armnetwork.NewClientFactory(subscriptionID, credential, resources.options.ClientOptions)
+	if err != nil {
+		return fmt.Errorf("CollectResourcesFromAPI error creating network clients: %w", err)
+	}
+	computeClients, err := armcompute.NewClientFactory(subscriptionID, credential, resources.options.ClientOptions)
+	if err != nil {
+		return fmt.Errorf("CollectResourcesFromAPI error creating compute clients: %w", err)
+	}
+	collector := &subscriptionCollector{network: networkClients, compute: computeClients, resourceGroup: resources.resourceGroup}
+
+	err = resources.collectNetworkResources(collector)
+	if err != nil {
+		return err
+	}
+
+	vms, err := collector.getVirtualMachines()
+	if err != nil {
+		return err
+	}
+	resources.VirtualMachinesList = append(resources.VirtualMachinesList,
+		filterByLocation(vms, func(vm *armcompute.VirtualMachine) *string { return vm.Location }, resources.regions)...)
+
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/azure"
+)
+
+const (
+	recordingsFile     = "data/recordings.json"
+	expectedOutputFile = "data/azure_example.json"
+	subscriptionID     = "11111111-2222-3333-4444-555555555555"
+)
+
+// recording is a recorded response of the Azure API to a request
+type recording struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// replayTransport answers requests with recorded responses, matched by method and path (ignoring the query)
+type replayTransport struct {
+	recordings []recording
+}
+
+func (transport *replayTransport) Do(req *http.Request) (*http.Response, error) {
+	for i := range transport.recordings {
+		rec := &transport.recordings[i]
+		if rec.Method == req.Method && strings.EqualFold(rec.Path, req.URL.Path) {
+			return newResponse(req, rec.Status, rec.Body), nil
+		}
+	}
+	return newResponse(req, http.StatusNotFound, []byte(`{"error":{"code":"NotRecorded","message":"no recorded response"}}`)), nil
+}
+
+func newResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}
+}
+
+// fakeCredential provides a fake token, accepted by replayTransport
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(context.Context, policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "fake-token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+func newReplayContainer(t *testing.T, regions []string) *azure.ResourcesContainer {
+	byteSlice, err := os.ReadFile(recordingsFile)
+	if err != nil {
+		t.Fatalf("couldn't read file: %s", recordingsFile)
+	}
+	transport := &replayTransport{}
+	err = json.Unmarshal(byteSlice, &transport.recordings)
+	if err != nil {
+		t.Fatalf("Unmarshal failed with error message: %v", err)
+	}
+	return azure.NewResourcesContainer(regions, "", &azure.CollectOptions{
+		SubscriptionIDs: []string{subscriptionID},
+		Credential:      fakeCredential{},
+		ClientOptions:   &arm.ClientOptions{ClientOptions: policy.ClientOptions{Transport: transport}},
+	})
+}
+
+func TestCollectFromRecordings(t *testing.T) {
+	resources := newReplayContainer(t, nil)
+	err := resources.CollectResourcesFromAPI()
+	if err != nil {
+		t.Fatalf("CollectResourcesFromAPI failed: %v", err)
+	}
+	toPrint, err := resources.ToJSONString()
+	if err != nil {
+		t.Fatalf("ToJSONString failed: %v", err)
+	}
+
+	expected, err := os.ReadFile(expectedOutputFile)
+	if err != nil {
+		t.Fatalf("couldn't read file: %s", expectedOutputFile)
+	}
+	if string(expected) != toPrint {
+		t.Errorf("Collected resources differ from %s", expectedOutputFile)
+
+		// Used for debugging test failures:
+		err = os.WriteFile("collect_output.json", []byte(toPrint), 0o600)
+		if err != nil {
+			t.Errorf("failed with error %v", err)
+		}
+	}
+}
+
+func TestCollectFromRecordingsWithRegion(t *testing.T) {
+	resources := newReplayContainer(t, []string{"westus"})
+	err := resources.CollectResourcesFromAPI()
+	if err != nil {
+		t.Fatalf("CollectResourcesFromAPI failed: %v", err)
+	}
+	if len(resources.VirtualNetworksList) != 1 || *resources.VirtualNetworksList[0].Name != "vnet-2" {
+		t.Errorf("expected only vnet-2 to be collected from westus, got %d virtual networks", len(resources.VirtualNetworksList))
+	}
+	if len(resources.SubnetsList) != 1 || len(resources.VirtualMachinesList) != 0 || len(resources.NSGList) != 0 {
+		t.Errorf("expected only the resources of westus to be collected")
+	}
+}
+
+func TestCollectFromRecordingsWithUnknownRegion(t *testing.T) {
+	resources := newReplayContainer(t, []string{"westus", "nowhere"})
+	err := resources.CollectResourcesFromAPI()
+	if err != nil {
+		t.Fatalf("CollectResourcesFromAPI failed: %v", err)
+	}
+	if len(resources.VirtualNetworksList) != 1 || *resources.VirtualNetworksList[0].Name != "vnet-2" {
+		t.Errorf("expected only vnet-2 to be collected from westus, got %d virtual networks", len(resources.VirtualNetworksList))
+	}
+
+	resources = newReplayContainer(t, []string{"nowhere"})
+	err = resources.CollectResourcesFromAPI()
+	if err == nil {
+		t.Errorf("expected CollectResourcesFromAPI to fail with no known region")
+	}
+}
@@ -15,12 +15,17 @@ import (
 type Provider string
 
 const (
-	AWS Provider = "aws"
-	IBM Provider = "ibm"
+	AWS   Provider = "aws"
+	Azure Provider = "azure"
+	GCP   Provider = "gcp"
+	IBM   Provider = "ibm"
+	K8s   Provider = "k8s"
 )
 
-var AllProviders = []string{string(IBM), string(AWS)}
-var AllProvidersStr = strings.Join(AllProviders, ", ")
+// AllProvidersStr returns the names of all registered providers as a comma-separated string
+func AllProvidersStr() string {
+	return strings.Join(AllProviders(), ", ")
+}
 
 func (p *Provider) String() string {
 	return string(*p)
@@ -28,11 +33,11 @@ func (p *Provider) String() string {
 
 func (p *Provider) Set(v string) error {
 	v = strings.ToLower(v)
-	if slices.Contains(AllProviders, v) {
+	if slices.Contains(AllProviders(), v) {
 		*p = Provider(v)
 		return nil
 	}
-	return fmt.Errorf("must be one of [%s]", AllProvidersStr)
+	return fmt.Errorf("must be one of [%s]", AllProvidersStr())
 }
 
 func (p *Provider) Type() string {
@@ -0,0 +1,75 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/pflag"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/tfexport"
+	"github.com/np-guard/cloud-resource-collector/pkg/tfstate"
+)
+
+// ProviderInfo describes a cloud provider from which resources can be collected
+type ProviderInfo struct {
+	// Name is the value of the --provider flag selecting this provider
+	Name Provider
+	// NewResourcesContainer creates an empty resources container for collecting resources from the given regions
+	// (all regions if empty) and resource group (all resource groups if empty)
+	NewResourcesContainer func(regions []string, resourceGroup string) ResourcesContainerInf
+	// AllRegions returns all the regions available for this provider
+	AllRegions func() []string
+	// SupportsFabricate is true if the provider can generate synthetic data
+	SupportsFabricate bool
+	// SupportsResourceGroups is true if collection can be restricted to a single resource group
+	SupportsResourceGroups bool
+	// FromTerraformState translates the resources in a Terraform state into a resources container, without
+	// calling the provider API (nil if the provider does not support Terraform state)
+	FromTerraformState func(state *tfstate.State) (ResourcesContainerInf, error)
+	// ExportTerraform generates a Terraform configuration recreating the resources in a snapshot (the output of the
+	// collect command), without calling the provider API (nil if the provider does not support exporting)
+	ExportTerraform func(snapshot []byte) (*tfexport.Config, error)
+	// Flags holds provider-specific flags of the collect command (may be nil).
+	// The values of these flags should be bound to the options used by NewResourcesContainer
+	Flags *pflag.FlagSet
+}
+
+var providers = map[Provider]*ProviderInfo{}
+
+// RegisterProvider adds a provider to the registry of providers available to the collector.
+// Providers outside this repository can be made available by registering them before the CLI is built
+func RegisterProvider(info *ProviderInfo) error {
+	if info.Name == "" || info.NewResourcesContainer == nil || info.AllRegions == nil {
+		return fmt.Errorf("provider %q must have a name, a resources container constructor and a regions function", info.Name)
+	}
+	if _, ok := providers[info.Name]; ok {
+		return fmt.Errorf("provider %s is already registered", info.Name)
+	}
+	providers[info.Name] = info
+	return nil
+}
+
+// GetProvider returns the registered provider with the given name
+func GetProvider(name Provider) (*ProviderInfo, error) {
+	info, ok := providers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %s. Supported providers: %s", name, AllProvidersStr())
+	}
+	return info, nil
+}
+
+// AllProviders returns the names of all registered providers, sorted
+func AllProviders() []string {
+	res := make([]string, 0, len(providers))
+	for name := range providers {
+		res = append(res, string(name))
+	}
+	slices.Sort(res)
+	return res
+}
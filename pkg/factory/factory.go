@@ -8,17 +8,30 @@ package factory
 
 import (
 	"github.com/np-guard/cloud-resource-collector/pkg/aws"
+	"github.com/np-guard/cloud-resource-collector/pkg/azure"
 	"github.com/np-guard/cloud-resource-collector/pkg/common"
+	"github.com/np-guard/cloud-resource-collector/pkg/gcp"
 	"github.com/np-guard/cloud-resource-collector/pkg/ibm"
+	"github.com/np-guard/cloud-resource-collector/pkg/k8s"
 )
 
-func GetResourceContainer(provider common.Provider, regions []string, resourceGroup string) common.ResourcesContainerInf {
-	var resources common.ResourcesContainerInf
-	switch provider {
-	case common.AWS:
-		resources = aws.NewResourcesContainer(regions)
-	case common.IBM:
-		resources = ibm.NewResourcesContainer(regions, resourceGroup)
+// RegisterBuiltinProviders registers all the providers implemented in this repository
+func RegisterBuiltinProviders() error {
+	builtinProviders := []func() error{aws.RegisterProvider, azure.RegisterProvider, gcp.RegisterProvider, ibm.RegisterProvider,
+		k8s.RegisterProvider}
+	for _, register := range builtinProviders {
+		if err := register(); err != nil {
+			return err
+		}
 	}
-	return resources
+	return nil
+}
+
+// GetResourceContainer creates an empty resources container of a registered provider
+func GetResourceContainer(provider common.Provider, regions []string, resourceGroup string) (common.ResourcesContainerInf, error) {
+	info, err := common.GetProvider(provider)
+	if err != nil {
+		return nil, err
+	}
+	return info.NewResourcesContainer(regions, resourceGroup), nil
 }
@@ -0,0 +1,156 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/compute/v1"
+)
+
+const (
+	globalScope = "global"
+	zonesScope  = "zones/"
+)
+
+// collectProjectResources uses the compute API to collect the resources of a single project.
+// Networks, firewall rules and routes are global, and are collected regardless of the requested regions
+func (resources *ResourcesContainer) collectProjectResources(service *compute.Service, projectID string) error {
+	err := resources.collectNetworks(service, projectID)
+	if err != nil {
+		return err
+	}
+
+	err = service.Firewalls.List(projectID).Pages(context.TODO(), func(page *compute.FirewallList) error {
+		resources.FirewallsList = append(resources.FirewallsList, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[collectProjectResources] error getting firewall rules: %w", err)
+	}
+
+	err = service.Routes.List(projectID).Pages(context.TODO(), func(page *compute.RouteList) error {
+		resources.RoutesList = append(resources.RoutesList, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[collectProjectResources] error getting routes: %w", err)
+	}
+
+	return resources.collectRegionalResources(service, projectID)
+}
+
+// collectNetworks collects all VPC networks of a project, with their effective firewall policies and their peerings
+func (resources *ResourcesContainer) collectNetworks(service *compute.Service, projectID string) error {
+	networks := []*compute.Network{}
+	err := service.Networks.List(projectID).Pages(context.TODO(), func(page *compute.NetworkList) error {
+		networks = append(networks, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[collectNetworks] error getting networks: %w", err)
+	}
+
+	for _, network := range networks {
+		effective, err := service.Networks.GetEffectiveFirewalls(projectID, network.Name).Context(context.TODO()).Do()
+		if err != nil {
+			return fmt.Errorf("[collectNetworks] error getting effective firewalls of network %s: %w", network.Name, err)
+		}
+		policies := effective.FirewallPolicys
+		if policies == nil {
+			policies = []*compute.NetworksGetEffectiveFirewallsResponseEffectiveFirewallPolicy{}
+		}
+		resources.NetworksList = append(resources.NetworksList, &Network{Network: network, EffectiveFirewallPolicies: policies})
+		for _, peering := range network.Peerings {
+			resources.VpcPeeringsList = append(resources.VpcPeeringsList, &VpcPeering{Network: network.SelfLink, Peering: peering})
+		}
+	}
+	return nil
+}
+
+// collectRegionalResources collects the regional and zonal resources of a project, from the requested regions
+func (resources *ResourcesContainer) collectRegionalResources(service *compute.Service, projectID string) error {
+	err := service.Subnetworks.AggregatedList(projectID).Pages(context.TODO(), func(page *compute.SubnetworkAggregatedList) error {
+		resources.SubnetworksList = append(resources.SubnetworksList, scopedItems(page.Items, resources.regions,
+			func(list compute.SubnetworksScopedList) []*compute.Subnetwork { return list.Subnetworks })...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[collectRegionalResources] error getting subnetworks: %w", err)
+	}
+
+	err = service.Routers.AggregatedList(projectID).Pages(context.TODO(), func(page *compute.RouterAggregatedList) error {
+		resources.RoutersList = append(resources.RoutersList, scopedItems(page.Items, resources.regions,
+			func(list compute.RoutersScopedList) []*compute.Router { return list.Routers })...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[collectRegionalResources] error getting routers: %w", err)
+	}
+
+	err = service.Instances.AggregatedList(projectID).Pages(context.TODO(), func(page *compute.InstanceAggregatedList) error {
+		resources.InstancesList = append(resources.InstancesList, scopedItems(page.Items, resources.regions,
+			func(list compute.InstancesScopedList) []*compute.Instance { return list.Instances })...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[collectRegionalResources] error getting instances: %w", err)
+	}
+
+	err = service.ForwardingRules.AggregatedList(projectID).Pages(context.TODO(), func(page *compute.ForwardingRuleAggregatedList) error {
+		resources.ForwardingRulesList = append(resources.ForwardingRulesList, scopedItems(page.Items, resources.regions,
+			func(list compute.ForwardingRulesScopedList) []*compute.ForwardingRule { return list.ForwardingRules })...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("[collectRegionalResources] error getting forwarding rules: %w", err)
+	}
+
+	err = service.NetworkFirewallPolicies.AggregatedList(projectID).Pages(context.TODO(),
+		func(page *compute.NetworkFirewallPolicyAggregatedList) error {
+			resources.FirewallPoliciesList = append(resources.FirewallPoliciesList, scopedItems(page.Items, resources.regions,
+				func(list compute.FirewallPoliciesScopedList) []*compute.FirewallPolicy { return list.FirewallPolicies })...)
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("[collectRegionalResources] error getting network firewall policies: %w", err)
+	}
+
+	return nil
+}
+
+// scopedItems returns the items of an aggregated list, from the scopes ("global", "regions/<region>" or
+// "zones/<zone>") in the given regions (from all scopes if no regions are given). Scopes are sorted for a stable output
+func scopedItems[S, T any](items map[string]S, regions []string, getItems func(S) []*T) []*T {
+	scopes := make([]string, 0, len(items))
+	for scope := range items {
+		if scopeInRegions(scope, regions) {
+			scopes = append(scopes, scope)
+		}
+	}
+	slices.Sort(scopes)
+
+	res := []*T{}
+	for _, scope := range scopes {
+		res = append(res, getItems(items[scope])...)
+	}
+	return res
+}
+
+func scopeInRegions(scope string, regions []string) bool {
+	if len(regions) == 0 || scope == globalScope {
+		return true
+	}
+	region := scope[strings.Index(scope, "/")+1:]
+	if strings.HasPrefix(scope, zonesScope) {
+		region = region[:strings.LastIndex(region, "-")]
+	}
+	return slices.Contains(regions, region)
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gcp
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/common"
+)
+
+// RegisterProvider registers the GCP provider, together with its collect flags
+func RegisterProvider() error {
+	options := &CollectOptions{}
+	flags := pflag.NewFlagSet(string(common.GCP), pflag.ContinueOnError)
+	flags.StringArrayVar(&options.ProjectIDs, "gcp-project", nil,
+		"GCP project id from which to collect resources (default: all active projects)")
+
+	return common.RegisterProvider(&common.ProviderInfo{
+		Name: common.GCP,
+		NewResourcesContainer: func(regions []string, _ string) common.ResourcesContainerInf {
+			return NewResourcesContainer(regions, options)
+		},
+		AllRegions: func() []string { return gcpRegions },
+		Flags:      flags,
+	})
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/common"
+	"github.com/np-guard/cloud-resource-collector/pkg/version"
+)
+
+// ResourcesContainer holds the results of collecting the configurations of all resources.
+// This includes: firewall policies, firewall rules, forwarding rules, VM instances, VPC networks, Cloud Routers
+// (with their Cloud NAT configurations), routes, subnetworks and VPC peerings
+type ResourcesContainer struct {
+	common.ResourceModelMetadata
+	FirewallPoliciesList []*compute.FirewallPolicy `json:"firewall_policies"`
+	FirewallsList        []*compute.Firewall       `json:"firewalls"`
+	ForwardingRulesList  []*compute.ForwardingRule `json:"forwarding_rules"`
+	InstancesList        []*compute.Instance       `json:"instances"`
+	NetworksList         []*Network                `json:"networks"`
+	RoutersList          []*compute.Router         `json:"routers"`
+	RoutesList           []*compute.Route          `json:"routes"`
+	SubnetworksList      []*compute.Subnetwork     `json:"subnetworks"`
+	VpcPeeringsList      []*VpcPeering             `json:"vpc_peerings"`
+	regions              []string
+	options              CollectOptions
+}
+
+// CollectOptions holds the GCP-specific options for collecting resources
+type CollectOptions struct {
+	// ProjectIDs are the projects from which to collect resources. If empty, resources are collected
+	// from all active projects accessible with the application default credentials
+	ProjectIDs []string
+	// ClientOptions are passed to all GCP clients, e.g., for using canned API responses in tests
+	ClientOptions []option.ClientOption
+}
+
+// Network is a VPC network, together with the firewall policies in effect for it. These include
+// hierarchical firewall policies inherited from the organization and folders, and network firewall policies
+type Network struct {
+	Network                   *compute.Network                                                        `json:"network"`
+	EffectiveFirewallPolicies []*compute.NetworksGetEffectiveFirewallsResponseEffectiveFirewallPolicy `json:"effective_firewall_policies"`
+}
+
+// VpcPeering is a peering of a VPC network (identified by its self link) with another VPC network
+type VpcPeering struct {
+	Network string                  `json:"network"`
+	Peering *compute.NetworkPeering `json:"peering"`
+}
+
+// NewResourcesContainer creates an empty resources container
+func NewResourcesContainer(regions []string, options *CollectOptions) *ResourcesContainer {
+	if options == nil {
+		options = &CollectOptions{}
+	}
+	return &ResourcesContainer{
+		FirewallPoliciesList:  []*compute.FirewallPolicy{},
+		FirewallsList:         []*compute.Firewall{},
+		ForwardingRulesList:   []*compute.ForwardingRule{},
+		InstancesList:         []*compute.Instance{},
+		NetworksList:          []*Network{},
+		RoutersList:           []*compute.Router{},
+		RoutesList:            []*compute.Route{},
+		SubnetworksList:       []*compute.Subnetwork{},
+		VpcPeeringsList:       []*VpcPeering{},
+		ResourceModelMetadata: common.ResourceModelMetadata{Version: version.VersionCore, Provider: string(common.GCP)},
+		regions:               regions,
+		options:               *options,
+	}
+}
+
+// PrintStats outputs the number of items of each type
+func (resources *ResourcesContainer) PrintStats() {
+	fmt.Printf("Found %d firewall policies\n", len(resources.FirewallPoliciesList))
+	fmt.Printf("Found %d firewall rules\n", len(resources.FirewallsList))
+	fmt.Printf("Found %d forwarding rules\n", len(resources.ForwardingRulesList))
+	fmt.Printf("Found %d instances\n", len(resources.InstancesList))
+	fmt.Printf("Found %d VPC networks\n", len(resources.NetworksList))
+	fmt.Printf("Found %d Cloud Routers\n", len(resources.RoutersList))
+	fmt.Printf("Found %d routes\n", len(resources.RoutesList))
+	fmt.Printf("Found %d subnetworks\n", len(resources.SubnetworksList))
+	fmt.Printf("Found %d VPC peerings\n", len(resources.VpcPeeringsList))
+}
+
+// ToJSONString converts a ResourcesContainer into a json-formatted-string
+func (resources *ResourcesContainer) ToJSONString() (string, error) {
+	toPrint, err := json.MarshalIndent(resources, "", "    ")
+	return string(toPrint), err
+}
+
+func (resources *ResourcesContainer) AllRegions() []string {
+	return gcpRegions
+}
+
+func (resources *ResourcesContainer) GetResources() common.ResourcesModel {
+	return resources
+}
+
+func (resources *ResourcesContainer) Fabricate(opts *common.FabricateOptions) {
+}
+
+// CollectResourcesFromAPI uses GCP APIs to collect resource configuration information
+func (resources *ResourcesContainer) CollectResourcesFromAPI() error {
+	for _, region := range resources.regions {
+		if !slices.Contains(gcpRegions, region) {
+			log.Printf("Unknown region %s. Available regions for provider gcp: %s\n", region, strings.Join(gcpRegions, ", "))
+		}
+	}
+
+	service, err := compute.NewService(context.TODO(), resources.options.ClientOptions...)
+	if err != nil {
+		return fmt.Errorf("CollectResourcesFromAPI error creating compute service: %w", err)
+	}
+
+	projectIDs := resources.options.ProjectIDs
+	if len(projectIDs) == 0 {
+		projectIDs, err = resources.getProjectIDs()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, projectID := range projectIDs {
+		log.Printf("Collecting resources from project %s\n", projectID)
+		err := resources.collectProjectResources(service, projectID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getProjectIDs returns the IDs of all active projects accessible with the application default credentials
+func (resources *ResourcesContainer) getProjectIDs() ([]string, error) {
+	service, err := cloudresourcemanager.NewService(context.TODO(), resources.options.ClientOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("CollectResourcesFromAPI error creating resource manager service: %w", err)
+	}
+	res := []string{}
+	err = service.Projects.List().Filter("lifecycleState:ACTIVE").Pages(context.TODO(),
+		func(page *cloudresourcemanager.ListProjectsResponse) error {
+			for _, project := range page.Projects {
+				res = append(res, project.ProjectId)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("CollectResourcesFromAPI error listing projects: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"google.golang.org/api/option"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/gcp"
+)
+
+const (
+	responsesFile      = "data/responses.json"
+	expectedOutputFile = "data/gcp_example.json"
+	projectID          = "example-project"
+)
+
+// newCannedServer returns a server answering requests with canned responses, matched by path (ignoring the query)
+func newCannedServer(t *testing.T) *httptest.Server {
+	byteSlice, err := os.ReadFile(responsesFile)
+	if err != nil {
+		t.Fatalf("couldn't read file: %s", responsesFile)
+	}
+	responses := map[string]json.RawMessage{}
+	err = json.Unmarshal(byteSlice, &responses)
+	if err != nil {
+		t.Fatalf("Unmarshal failed with error message: %v", err)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(response)
+	}))
+}
+
+func newCannedContainer(server *httptest.Server, regions []string) *gcp.ResourcesContainer {
+	return gcp.NewResourcesContainer(regions, &gcp.CollectOptions{
+		ProjectIDs: []string{projectID},
+		ClientOptions: []option.ClientOption{
+			option.WithEndpoint(server.URL + "/compute/v1/"),
+			option.WithHTTPClient(server.Client()),
+		},
+	})
+}
+
+func TestCollectFromCannedResponses(t *testing.T) {
+	server := newCannedServer(t)
+	defer server.Close()
+
+	resources := newCannedContainer(server, nil)
+	err := resources.CollectResourcesFromAPI()
+	if err != nil {
+		t.Fatalf("CollectResourcesFromAPI failed: %v", err)
+	}
+	toPrint, err := resources.ToJSONString()
+	if err != nil {
+		t.Fatalf("ToJSONString failed: %v", err)
+	}
+
+	expected, err := os.ReadFile(expectedOutputFile)
+	if err != nil {
+		t.Fatalf("couldn't read file: %s", expectedOutputFile)
+	}
+	if string(expected) != toPrint {
+		t.Errorf("Collected resources differ from %s", expectedOutputFile)
+
+		// Used for debugging test failures:
+		err = os.WriteFile("collect_output.json", []byte(toPrint), 0o600)
+		if err != nil {
+			t.Errorf("failed with error %v", err)
+		}
+	}
+}
+
+func TestCollectFromCannedResponsesWithRegion(t *testing.T) {
+	server := newCannedServer(t)
+	defer server.Close()
+
+	resources := newCannedContainer(server, []string{"europe-west1"})
+	err := resources.CollectResourcesFromAPI()
+	if err != nil {
+		t.Fatalf("CollectResourcesFromAPI failed: %v", err)
+	}
+	if len(resources.SubnetworksList) != 1 || resources.SubnetworksList[0].Name != "subnet-2" {
+		t.Errorf("expected only subnet-2 to be collected from europe-west1, got %d subnetworks", len(resources.SubnetworksList))
+	}
+	if len(resources.InstancesList) != 1 || resources.InstancesList[0].Name != "vm-2" {
+		t.Errorf("expected only vm-2 to be collected from europe-west1, got %d instances", len(resources.InstancesList))
+	}
+	if len(resources.NetworksList) != 2 || len(resources.FirewallPoliciesList) != 1 || len(resources.RoutersList) != 0 {
+		t.Errorf("expected global resources and only the regional resources of europe-west1 to be collected")
+	}
+}
@@ -8,13 +8,21 @@ package datamodel
 
 import (
 	"encoding/json"
+	"fmt"
 
+	"github.com/IBM/networking-go-sdk/directlinkv1"
+	"github.com/IBM/networking-go-sdk/dnssvcsv1"
 	tgw "github.com/IBM/networking-go-sdk/transitgatewayapisv1"
+	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
 
 	iksv1 "github.com/IBM-Cloud/container-services-go-sdk/kubernetesserviceapiv1"
+	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 )
 
+// redacted replaces secrets (e.g., pre-shared keys) in collected resources
+const redacted = "<redacted>"
+
 // Helper function for unmarshalling
 
 func jsonToMap(jsonStr []byte) (map[string]json.RawMessage, error) {
@@ -177,7 +185,8 @@ func (res *NetworkACL) UnmarshalJSON(data []byte) error {
 	return basicUnmarshal(data, vpcv1.UnmarshalNetworkACL, &res.NetworkACL, &res.BaseTaggedResource)
 }
 
-// SecurityGroup configuration object
+// SecurityGroup configuration object. When collected, its targets are all targets of the group (from all pages of
+// ListSecurityGroupTargets), rather than those listed with the group
 type SecurityGroup struct {
 	vpcv1.SecurityGroup
 	BaseTaggedResource
@@ -212,16 +221,50 @@ func (res *EndpointGateway) UnmarshalJSON(data []byte) error {
 // Instance configuration object
 type Instance struct {
 	vpcv1.Instance
-	NetworkInterfaces []vpcv1.NetworkInterface `json:"network_interfaces"`
+	NetworkInterfaces  []vpcv1.NetworkInterface          `json:"network_interfaces"`
+	NetworkAttachments []vpcv1.InstanceNetworkAttachment `json:"network_attachments"`
 	BaseTaggedResource
 }
 
-func NewInstance(instance *vpcv1.Instance, networkInterfaces []vpcv1.NetworkInterface) *Instance {
-	return &Instance{Instance: *instance, NetworkInterfaces: networkInterfaces}
+func NewInstance(instance *vpcv1.Instance, networkInterfaces []vpcv1.NetworkInterface,
+	networkAttachments []vpcv1.InstanceNetworkAttachment) *Instance {
+	return &Instance{Instance: *instance, NetworkInterfaces: networkInterfaces, NetworkAttachments: networkAttachments}
 }
 
 func (res *Instance) GetCRN() *string { return res.CRN }
 
+// BareMetalServer configuration object, with detailed network interfaces (PCI, VLAN or hipersocket) and network attachments
+// (binding virtual network interfaces). These are kept in their SDK interface types, as the fields differ between types
+type BareMetalServer struct {
+	vpcv1.BareMetalServer
+	NetworkInterfaces  []vpcv1.BareMetalServerNetworkInterfaceIntf  `json:"network_interfaces"`
+	NetworkAttachments []vpcv1.BareMetalServerNetworkAttachmentIntf `json:"network_attachments"`
+	BaseTaggedResource
+}
+
+func NewBareMetalServer(bareMetalServer *vpcv1.BareMetalServer, networkInterfaces []vpcv1.BareMetalServerNetworkInterfaceIntf,
+	networkAttachments []vpcv1.BareMetalServerNetworkAttachmentIntf) *BareMetalServer {
+	return &BareMetalServer{BareMetalServer: *bareMetalServer, NetworkInterfaces: networkInterfaces, NetworkAttachments: networkAttachments}
+}
+
+func (res *BareMetalServer) GetCRN() *string { return res.CRN }
+
+func (res *BareMetalServer) UnmarshalJSON(data []byte) error {
+	err := basicUnmarshal(data, vpcv1.UnmarshalBareMetalServer, &res.BareMetalServer, &res.BaseTaggedResource)
+	if err != nil {
+		return err
+	}
+	asMap, err := jsonToMap(data)
+	if err != nil {
+		return err
+	}
+	err = core.UnmarshalModel(asMap, "network_interfaces", &res.NetworkInterfaces, vpcv1.UnmarshalBareMetalServerNetworkInterface)
+	if err != nil {
+		return err
+	}
+	return core.UnmarshalModel(asMap, "network_attachments", &res.NetworkAttachments, vpcv1.UnmarshalBareMetalServerNetworkAttachment)
+}
+
 // Virtual Network Interface object
 type VirtualNI struct {
 	vpcv1.VirtualNetworkInterface
@@ -238,6 +281,22 @@ func (res *VirtualNI) UnmarshalJSON(data []byte) error {
 	return basicUnmarshal(data, vpcv1.UnmarshalVirtualNetworkInterface, &res.VirtualNetworkInterface, &res.BaseTaggedResource)
 }
 
+// FlowLogCollector configuration object; its target is a VPC, subnet, instance network interface or attachment, or VNI
+type FlowLogCollector struct {
+	vpcv1.FlowLogCollector
+	BaseTaggedResource
+}
+
+func NewFlowLogCollector(flowLogCollector *vpcv1.FlowLogCollector) *FlowLogCollector {
+	return &FlowLogCollector{FlowLogCollector: *flowLogCollector}
+}
+
+func (res *FlowLogCollector) GetCRN() *string { return res.CRN }
+
+func (res *FlowLogCollector) UnmarshalJSON(data []byte) error {
+	return basicUnmarshal(data, vpcv1.UnmarshalFlowLogCollector, &res.FlowLogCollector, &res.BaseTaggedResource)
+}
+
 // RoutingTable configuration object (not taggable)
 type RoutingTable struct {
 	vpcv1.RoutingTable
@@ -263,6 +322,229 @@ func (res *RouteWrapper) UnmarshalJSON(data []byte) error {
 	return basicUnmarshal(data, vpcv1.UnmarshalRoute, &res.Route, nil)
 }
 
+// VPNGatewayConnection configuration object, with its local and peer CIDRs (policy-mode connections).
+// Connections of both modes are kept in the generic SDK type, and their pre-shared key is redacted
+type VPNGatewayConnection struct {
+	vpcv1.VPNGatewayConnection
+	LocalCIDRs []string `json:"local_cidrs"`
+	PeerCIDRs  []string `json:"peer_cidrs"`
+}
+
+// NewVPNGatewayConnection creates a connection object, taking the local and peer CIDRs of a policy-mode connection
+// from the connection itself
+func NewVPNGatewayConnection(connection vpcv1.VPNGatewayConnectionIntf) (VPNGatewayConnection, error) {
+	asObj, err := toVPNGatewayConnection(connection)
+	if err != nil {
+		return VPNGatewayConnection{}, err
+	}
+	localCIDRs, peerCIDRs := policyModeCIDRs(connection)
+	return VPNGatewayConnection{VPNGatewayConnection: *asObj, LocalCIDRs: localCIDRs, PeerCIDRs: peerCIDRs}, nil
+}
+
+func (res *VPNGatewayConnection) UnmarshalJSON(data []byte) error {
+	asMap, err := jsonToMap(data)
+	if err != nil {
+		return err
+	}
+	var connection vpcv1.VPNGatewayConnectionIntf
+	err = vpcv1.UnmarshalVPNGatewayConnection(asMap, &connection)
+	if err != nil {
+		return err
+	}
+	asObj, err := toVPNGatewayConnection(connection)
+	if err != nil {
+		return err
+	}
+	res.VPNGatewayConnection = *asObj
+
+	// the CIDRs are in local_cidrs and peer_cidrs, or (in connections as returned by the API) in local and peer
+	res.LocalCIDRs, res.PeerCIDRs = policyModeCIDRs(connection)
+	for key, cidrs := range map[string]*[]string{"local_cidrs": &res.LocalCIDRs, "peer_cidrs": &res.PeerCIDRs} {
+		val, ok := asMap[key]
+		if !ok {
+			continue
+		}
+		var keyCIDRs []string
+		if err := json.Unmarshal(val, &keyCIDRs); err != nil {
+			return err
+		}
+		if keyCIDRs != nil {
+			*cidrs = keyCIDRs
+		}
+	}
+	return nil
+}
+
+// policyModeCIDRs returns the local and peer CIDRs of a policy-mode connection (nil for other connections)
+func policyModeCIDRs(connection vpcv1.VPNGatewayConnectionIntf) (localCIDRs, peerCIDRs []string) {
+	policyConnection, ok := connection.(*vpcv1.VPNGatewayConnectionPolicyMode)
+	if !ok {
+		return nil, nil
+	}
+	if policyConnection.Local != nil {
+		localCIDRs = policyConnection.Local.CIDRs
+	}
+	if peer, ok := policyConnection.Peer.(*vpcv1.VPNGatewayConnectionPolicyModePeer); ok {
+		peerCIDRs = peer.CIDRs
+	}
+	return localCIDRs, peerCIDRs
+}
+
+// toVPNGatewayConnection converts a connection of either mode into the generic SDK type, redacting its pre-shared key.
+// The generic type has no local and peer CIDRs, which are kept separately
+func toVPNGatewayConnection(connection vpcv1.VPNGatewayConnectionIntf) (*vpcv1.VPNGatewayConnection, error) {
+	var res vpcv1.VPNGatewayConnection
+	switch connection := connection.(type) {
+	case *vpcv1.VPNGatewayConnection:
+		res = *connection
+	case *vpcv1.VPNGatewayConnectionRouteMode:
+		res = vpcv1.VPNGatewayConnection(*connection)
+	case *vpcv1.VPNGatewayConnectionRouteModeVPNGatewayConnectionStaticRouteMode:
+		res = vpcv1.VPNGatewayConnection(*connection)
+	case *vpcv1.VPNGatewayConnectionPolicyMode:
+		res = vpcv1.VPNGatewayConnection{AdminStateUp: connection.AdminStateUp, AuthenticationMode: connection.AuthenticationMode,
+			CreatedAt: connection.CreatedAt, DeadPeerDetection: connection.DeadPeerDetection, EstablishMode: connection.EstablishMode,
+			Href: connection.Href, ID: connection.ID, IkePolicy: connection.IkePolicy, IpsecPolicy: connection.IpsecPolicy,
+			Mode: connection.Mode, Name: connection.Name, Psk: connection.Psk, ResourceType: connection.ResourceType,
+			Status: connection.Status, StatusReasons: connection.StatusReasons}
+		if connection.Local != nil {
+			res.Local = &vpcv1.VPNGatewayConnectionStaticRouteModeLocal{IkeIdentities: connection.Local.IkeIdentities}
+		}
+		if peer, ok := connection.Peer.(*vpcv1.VPNGatewayConnectionPolicyModePeer); ok {
+			res.Peer = &vpcv1.VPNGatewayConnectionStaticRouteModePeer{IkeIdentity: peer.IkeIdentity, Type: peer.Type,
+				Address: peer.Address, Fqdn: peer.Fqdn}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported VPN gateway connection type %T", connection)
+	}
+	if res.Psk != nil {
+		psk := redacted
+		res.Psk = &psk
+	}
+	return &res, nil
+}
+
+// VPNGateway configuration object with explicit connections (not references).
+// Gateways of both modes are kept in the generic SDK type
+type VPNGateway struct {
+	vpcv1.VPNGateway
+	Connections []VPNGatewayConnection `json:"connections"`
+	BaseTaggedResource
+}
+
+func NewVPNGateway(vpnGateway vpcv1.VPNGatewayIntf, connections []VPNGatewayConnection) *VPNGateway {
+	return &VPNGateway{VPNGateway: *toVPNGateway(vpnGateway), Connections: connections}
+}
+
+func (res *VPNGateway) GetCRN() *string { return res.CRN }
+
+func (res *VPNGateway) UnmarshalJSON(data []byte) error {
+	asMap, err := jsonToMap(data)
+	if err != nil {
+		return err
+	}
+	var vpnGateway vpcv1.VPNGatewayIntf
+	err = vpcv1.UnmarshalVPNGateway(asMap, &vpnGateway)
+	if err != nil {
+		return err
+	}
+	res.VPNGateway = *toVPNGateway(vpnGateway)
+
+	if val, ok := asMap["connections"]; ok {
+		if err := json.Unmarshal(val, &res.Connections); err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(data, &res.BaseTaggedResource)
+}
+
+func toVPNGateway(vpnGateway vpcv1.VPNGatewayIntf) *vpcv1.VPNGateway {
+	var res vpcv1.VPNGateway
+	switch vpnGateway := vpnGateway.(type) {
+	case *vpcv1.VPNGateway:
+		res = *vpnGateway
+	case *vpcv1.VPNGatewayRouteMode:
+		res = vpcv1.VPNGateway(*vpnGateway)
+	case *vpcv1.VPNGatewayPolicyMode:
+		res = vpcv1.VPNGateway(*vpnGateway)
+	}
+	return &res
+}
+
+// VPNServer configuration object (client-to-site VPN), with its routes and clients
+type VPNServer struct {
+	vpcv1.VPNServer
+	Routes  []vpcv1.VPNServerRoute  `json:"routes"`
+	Clients []vpcv1.VPNServerClient `json:"clients"`
+	BaseTaggedResource
+}
+
+// NewVPNServer creates a VPNServer. The personal data of the clients (common name, username and remote address) is dropped
+func NewVPNServer(vpnServer *vpcv1.VPNServer, routes []vpcv1.VPNServerRoute, clients []vpcv1.VPNServerClient) *VPNServer {
+	for i := range clients {
+		clients[i].CommonName = nil
+		clients[i].Username = nil
+		clients[i].RemoteIP = nil
+		clients[i].RemotePort = nil
+	}
+	return &VPNServer{VPNServer: *vpnServer, Routes: routes, Clients: clients}
+}
+
+func (res *VPNServer) GetCRN() *string { return res.CRN }
+
+func (res *VPNServer) UnmarshalJSON(data []byte) error {
+	asMap, err := jsonToMap(data)
+	if err != nil {
+		return err
+	}
+	asObj := &vpcv1.VPNServer{}
+	err = vpcv1.UnmarshalVPNServer(asMap, &asObj)
+	if err != nil {
+		return err
+	}
+	res.VPNServer = *asObj
+
+	if val, ok := asMap["routes"]; ok {
+		if err := json.Unmarshal(val, &res.Routes); err != nil {
+			return err
+		}
+	}
+	if val, ok := asMap["clients"]; ok {
+		if err := json.Unmarshal(val, &res.Clients); err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(data, &res.BaseTaggedResource)
+}
+
+// IKEPolicy configuration object (not taggable)
+type IKEPolicy struct {
+	vpcv1.IkePolicy
+}
+
+func NewIKEPolicy(ikePolicy *vpcv1.IkePolicy) *IKEPolicy {
+	return &IKEPolicy{IkePolicy: *ikePolicy}
+}
+
+func (res *IKEPolicy) UnmarshalJSON(data []byte) error {
+	return basicUnmarshal(data, vpcv1.UnmarshalIkePolicy, &res.IkePolicy, nil)
+}
+
+// IPsecPolicy configuration object (not taggable)
+type IPsecPolicy struct {
+	vpcv1.IPsecPolicy
+}
+
+func NewIPsecPolicy(ipsecPolicy *vpcv1.IPsecPolicy) *IPsecPolicy {
+	return &IPsecPolicy{IPsecPolicy: *ipsecPolicy}
+}
+
+func (res *IPsecPolicy) UnmarshalJSON(data []byte) error {
+	return basicUnmarshal(data, vpcv1.UnmarshalIPsecPolicy, &res.IPsecPolicy, nil)
+}
+
 // LoadBalancer configuration objects
 
 // LoadBalancerPoolMemberWrapper is an alias to vpcv1.LoadBalancerPoolMember that allows us to override
@@ -371,21 +653,83 @@ func NewLoadBalancer(
 
 func (res *LoadBalancer) GetCRN() *string { return res.CRN }
 
-// TransitConnection configuration object
+// PrivatePathServiceGateway configuration object, with its account policies and the bindings of endpoint gateways (of
+// consumer accounts) to it. The backing load balancer is in the load balancers list
+type PrivatePathServiceGateway struct {
+	vpcv1.PrivatePathServiceGateway
+	AccountPolicies         []vpcv1.PrivatePathServiceGatewayAccountPolicy          `json:"account_policies"`
+	EndpointGatewayBindings []vpcv1.PrivatePathServiceGatewayEndpointGatewayBinding `json:"endpoint_gateway_bindings"`
+	BaseTaggedResource
+}
+
+func NewPrivatePathServiceGateway(ppsg *vpcv1.PrivatePathServiceGateway,
+	accountPolicies []vpcv1.PrivatePathServiceGatewayAccountPolicy,
+	endpointGatewayBindings []vpcv1.PrivatePathServiceGatewayEndpointGatewayBinding) *PrivatePathServiceGateway {
+	return &PrivatePathServiceGateway{PrivatePathServiceGateway: *ppsg, AccountPolicies: accountPolicies,
+		EndpointGatewayBindings: endpointGatewayBindings}
+}
+
+func (res *PrivatePathServiceGateway) GetCRN() *string { return res.CRN }
+
+// TransitConnection configuration object, with its prefix filters
 type TransitConnection struct {
 	tgw.TransitConnection
+	PrefixFilters []tgw.PrefixFilterCust `json:"prefix_filters"`
 }
 
-func NewTransitConnection(transitConnection *tgw.TransitConnection) *TransitConnection {
-	return &TransitConnection{TransitConnection: *transitConnection}
+func NewTransitConnection(transitConnection *tgw.TransitConnection, prefixFilters []tgw.PrefixFilterCust) *TransitConnection {
+	return &TransitConnection{TransitConnection: *transitConnection, PrefixFilters: prefixFilters}
 }
 
+// TransitGateway configuration object, with a route report of the routes it learns (nil if none could be generated)
 type TransitGateway struct {
 	tgw.TransitGateway
+	RouteReport *tgw.RouteReport `json:"route_report"`
+}
+
+func NewTransitGateway(transitGateway *tgw.TransitGateway, routeReport *tgw.RouteReport) *TransitGateway {
+	return &TransitGateway{TransitGateway: *transitGateway, RouteReport: routeReport}
+}
+
+// DirectLinkGateway configuration object, with its virtual connections (to VPCs and classic networks), its export and
+// import route filters, and the IDs of the transit gateway connections to it
+type DirectLinkGateway struct {
+	directlinkv1.GatewayCollectionGatewaysItem
+	VirtualConnections []directlinkv1.GatewayVirtualConnection `json:"virtual_connections"`
+	ExportRouteFilters []directlinkv1.RouteFilter              `json:"export_route_filters"`
+	ImportRouteFilters []directlinkv1.RouteFilter              `json:"import_route_filters"`
+	TransitConnections []string                                `json:"transit_connections"`
+	BaseTaggedResource
 }
 
-func NewTransitGateway(transitGateway *tgw.TransitGateway) *TransitGateway {
-	return &TransitGateway{TransitGateway: *transitGateway}
+func NewDirectLinkGateway(gateway *directlinkv1.GatewayCollectionGatewaysItem,
+	virtualConnections []directlinkv1.GatewayVirtualConnection,
+	exportRouteFilters, importRouteFilters []directlinkv1.RouteFilter) *DirectLinkGateway {
+	return &DirectLinkGateway{GatewayCollectionGatewaysItem: *gateway, VirtualConnections: virtualConnections,
+		ExportRouteFilters: exportRouteFilters, ImportRouteFilters: importRouteFilters, TransitConnections: []string{}}
+}
+
+func (res *DirectLinkGateway) GetCRN() *string { return res.Crn }
+
+func (res *DirectLinkGateway) UnmarshalJSON(data []byte) error {
+	err := basicUnmarshal(data, directlinkv1.UnmarshalGatewayCollectionGatewaysItem, &res.GatewayCollectionGatewaysItem,
+		&res.BaseTaggedResource)
+	if err != nil {
+		return err
+	}
+	asMap, err := jsonToMap(data)
+	if err != nil {
+		return err
+	}
+	for key, val := range map[string]any{"virtual_connections": &res.VirtualConnections, "export_route_filters": &res.ExportRouteFilters,
+		"import_route_filters": &res.ImportRouteFilters, "transit_connections": &res.TransitConnections} {
+		if raw, ok := asMap[key]; ok {
+			if err := json.Unmarshal(raw, val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // IKSWorkerNode configuration object
@@ -397,3 +741,39 @@ type IKSCluster struct {
 func NewCluster(cluster *iksv1.GetClustersResponse, getWorkerResponse []iksv1.GetWorkerResponse) *IKSCluster {
 	return &IKSCluster{GetClustersResponse: *cluster, WorkerNodes: getWorkerResponse}
 }
+
+// DNSInstance configuration object: a DNS Services instance, with its private zones and custom resolvers
+type DNSInstance struct {
+	resourcecontrollerv2.ResourceInstance
+	Zones           []DNSZone           `json:"zones"`
+	CustomResolvers []DNSCustomResolver `json:"custom_resolvers"`
+	BaseTaggedResource
+}
+
+func NewDNSInstance(instance *resourcecontrollerv2.ResourceInstance, zones []DNSZone, customResolvers []DNSCustomResolver) *DNSInstance {
+	return &DNSInstance{ResourceInstance: *instance, Zones: zones, CustomResolvers: customResolvers}
+}
+
+func (res *DNSInstance) GetCRN() *string { return res.CRN }
+
+// DNSZone configuration object: a private DNS zone, with its resource records and permitted networks (VPCs)
+type DNSZone struct {
+	dnssvcsv1.Dnszone
+	ResourceRecords   []dnssvcsv1.ResourceRecord   `json:"resource_records"`
+	PermittedNetworks []dnssvcsv1.PermittedNetwork `json:"permitted_networks"`
+}
+
+func NewDNSZone(zone *dnssvcsv1.Dnszone, resourceRecords []dnssvcsv1.ResourceRecord,
+	permittedNetworks []dnssvcsv1.PermittedNetwork) DNSZone {
+	return DNSZone{Dnszone: *zone, ResourceRecords: resourceRecords, PermittedNetworks: permittedNetworks}
+}
+
+// DNSCustomResolver configuration object, with its forwarding rules
+type DNSCustomResolver struct {
+	dnssvcsv1.CustomResolver
+	ForwardingRules []dnssvcsv1.ForwardingRule `json:"forwarding_rules"`
+}
+
+func NewDNSCustomResolver(resolver *dnssvcsv1.CustomResolver, forwardingRules []dnssvcsv1.ForwardingRule) DNSCustomResolver {
+	return DNSCustomResolver{CustomResolver: *resolver, ForwardingRules: forwardingRules}
+}
@@ -17,20 +17,29 @@ import (
 // ResourcesContainerModel defines the model of a container for all resource types we can collect
 type ResourcesContainerModel struct {
 	common.ResourceModelMetadata
-	VpcList               []*VPC               `json:"vpcs"`
-	SubnetList            []*Subnet            `json:"subnets"`
-	PublicGWList          []*PublicGateway     `json:"public_gateways"`
-	FloatingIPList        []*FloatingIP        `json:"floating_ips"`
-	NetworkACLList        []*NetworkACL        `json:"network_acls"`
-	SecurityGroupList     []*SecurityGroup     `json:"security_groups"`
-	EndpointGWList        []*EndpointGateway   `json:"endpoint_gateways"`
-	InstanceList          []*Instance          `json:"instances"`
-	VirtualNIList         []*VirtualNI         `json:"virtual_nis"`
-	RoutingTableList      []*RoutingTable      `json:"routing_tables"`
-	LBList                []*LoadBalancer      `json:"load_balancers"`
-	TransitConnectionList []*TransitConnection `json:"transit_connections"`
-	TransitGatewayList    []*TransitGateway    `json:"transit_gateways"`
-	IKSClusters           []*IKSCluster        `json:"iks_clusters"`
+	VpcList               []*VPC                       `json:"vpcs"`
+	SubnetList            []*Subnet                    `json:"subnets"`
+	PublicGWList          []*PublicGateway             `json:"public_gateways"`
+	FloatingIPList        []*FloatingIP                `json:"floating_ips"`
+	NetworkACLList        []*NetworkACL                `json:"network_acls"`
+	SecurityGroupList     []*SecurityGroup             `json:"security_groups"`
+	EndpointGWList        []*EndpointGateway           `json:"endpoint_gateways"`
+	InstanceList          []*Instance                  `json:"instances"`
+	BareMetalServerList   []*BareMetalServer           `json:"bare_metal_servers"`
+	VirtualNIList         []*VirtualNI                 `json:"virtual_nis"`
+	RoutingTableList      []*RoutingTable              `json:"routing_tables"`
+	LBList                []*LoadBalancer              `json:"load_balancers"`
+	PrivatePathSGList     []*PrivatePathServiceGateway `json:"private_path_service_gateways"`
+	FlowLogCollectorList  []*FlowLogCollector          `json:"flow_log_collectors"`
+	VPNGatewayList        []*VPNGateway                `json:"vpn_gateways"`
+	IKEPolicyList         []*IKEPolicy                 `json:"ike_policies"`
+	IPsecPolicyList       []*IPsecPolicy               `json:"ipsec_policies"`
+	VPNServerList         []*VPNServer                 `json:"vpn_servers"`
+	TransitConnectionList []*TransitConnection         `json:"transit_connections"`
+	TransitGatewayList    []*TransitGateway            `json:"transit_gateways"`
+	DirectLinkGatewayList []*DirectLinkGateway         `json:"directlink_gateways"`
+	IKSClusters           []*IKSCluster                `json:"iks_clusters"`
+	DNSInstanceList       []*DNSInstance               `json:"dns_instances"`
 }
 
 // NewResourcesContainerModel creates an empty resources container
@@ -44,12 +53,21 @@ func NewResourcesContainerModel() *ResourcesContainerModel {
 		SecurityGroupList:     []*SecurityGroup{},
 		EndpointGWList:        []*EndpointGateway{},
 		InstanceList:          []*Instance{},
+		BareMetalServerList:   []*BareMetalServer{},
 		VirtualNIList:         []*VirtualNI{},
 		RoutingTableList:      []*RoutingTable{},
 		LBList:                []*LoadBalancer{},
+		PrivatePathSGList:     []*PrivatePathServiceGateway{},
+		FlowLogCollectorList:  []*FlowLogCollector{},
+		VPNGatewayList:        []*VPNGateway{},
+		IKEPolicyList:         []*IKEPolicy{},
+		IPsecPolicyList:       []*IPsecPolicy{},
+		VPNServerList:         []*VPNServer{},
 		TransitConnectionList: []*TransitConnection{},
 		TransitGatewayList:    []*TransitGateway{},
+		DirectLinkGatewayList: []*DirectLinkGateway{},
 		IKSClusters:           []*IKSCluster{},
+		DNSInstanceList:       []*DNSInstance{},
 		ResourceModelMetadata: common.ResourceModelMetadata{Version: version.VersionCore, Provider: string(common.IBM)},
 	}
 }
@@ -64,12 +82,39 @@ func (resources *ResourcesContainerModel) PrintStats() {
 	fmt.Printf("Found %d security groups\n", len(resources.SecurityGroupList))
 	fmt.Printf("Found %d endpoint gateways (VPEs)\n", len(resources.EndpointGWList))
 	fmt.Printf("Found %d instances\n", len(resources.InstanceList))
+	fmt.Printf("Found %d bare metal servers\n", len(resources.BareMetalServerList))
 	fmt.Printf("Found %d virtual network interfaces\n", len(resources.VirtualNIList))
 	fmt.Printf("Found %d routing tables\n", len(resources.RoutingTableList))
 	fmt.Printf("Found %d load balancers\n", len(resources.LBList))
+	fmt.Printf("Found %d Private Path service gateways\n", len(resources.PrivatePathSGList))
+	fmt.Printf("Found %d flow log collectors (%d VPCs without a flow log collector)\n", len(resources.FlowLogCollectorList),
+		resources.vpcsWithoutFlowLogs())
+	fmt.Printf("Found %d VPN gateways\n", len(resources.VPNGatewayList))
+	fmt.Printf("Found %d IKE policies\n", len(resources.IKEPolicyList))
+	fmt.Printf("Found %d IPsec policies\n", len(resources.IPsecPolicyList))
+	fmt.Printf("Found %d VPN servers\n", len(resources.VPNServerList))
 	fmt.Printf("Found %d transit connections\n", len(resources.TransitConnectionList))
 	fmt.Printf("Found %d transit gateways\n", len(resources.TransitGatewayList))
+	fmt.Printf("Found %d Direct Link gateways\n", len(resources.DirectLinkGatewayList))
 	fmt.Printf("Found %d IKS clusters\n", len(resources.IKSClusters))
+	fmt.Printf("Found %d DNS Services instances\n", len(resources.DNSInstanceList))
+}
+
+// vpcsWithoutFlowLogs returns the number of VPCs with no flow log collector, for any of their resources
+func (resources *ResourcesContainerModel) vpcsWithoutFlowLogs() int {
+	withFlowLogs := map[string]bool{}
+	for _, collector := range resources.FlowLogCollectorList {
+		if collector.VPC != nil {
+			withFlowLogs[*collector.VPC.ID] = true
+		}
+	}
+	res := 0
+	for _, vpc := range resources.VpcList {
+		if !withFlowLogs[*vpc.ID] {
+			res++
+		}
+	}
+	return res
 }
 
 // ToJSONString converts a ResourcesContainerModel into a json-formatted-string
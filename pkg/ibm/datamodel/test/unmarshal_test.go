@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/np-guard/cloud-resource-collector/pkg/ibm/datamodel"
@@ -57,3 +58,29 @@ func TestUnmarshal(t *testing.T) {
 		}
 	}
 }
+
+// A policy-mode connection, as returned by the API, has its local and peer CIDRs in its local and peer
+const policyModeConnection = `{"admin_state_up": true, "authentication_mode": "psk", "created_at": "2024-05-12T08:25:02.000Z",
+	"dead_peer_detection": {"action": "restart", "interval": 2, "timeout": 10}, "establish_mode": "peer_only", "href": "href:1006",
+	"id": "id:1007", "mode": "policy", "name": "ky-testenv-policy-conn", "psk": "secret", "resource_type": "vpn_gateway_connection",
+	"status": "down", "status_reasons": [],
+	"local": {"cidrs": ["192.168.40.0/22"], "ike_identities": [{"type": "ipv4_address", "value": "169.63.100.10"}]},
+	"peer": {"cidrs": ["10.10.0.0/16", "10.20.0.0/16"], "ike_identity": {"type": "fqdn", "value": "vpn.example.com"},
+		"type": "fqdn", "fqdn": "vpn.example.com"}}`
+
+func TestUnmarshalPolicyModeConnection(t *testing.T) {
+	connection := datamodel.VPNGatewayConnection{}
+	err := json.Unmarshal([]byte(policyModeConnection), &connection)
+	if err != nil {
+		t.Fatalf("Unmarshal failed with error message: %v", err)
+	}
+	if !slices.Equal(connection.LocalCIDRs, []string{"192.168.40.0/22"}) {
+		t.Errorf("Unexpected local CIDRs %v", connection.LocalCIDRs)
+	}
+	if !slices.Equal(connection.PeerCIDRs, []string{"10.10.0.0/16", "10.20.0.0/16"}) {
+		t.Errorf("Unexpected peer CIDRs %v", connection.PeerCIDRs)
+	}
+	if *connection.Psk == "secret" {
+		t.Errorf("Pre-shared key was not redacted")
+	}
+}
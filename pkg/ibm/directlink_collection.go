@@ -0,0 +1,72 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ibm
+
+import (
+	"fmt"
+
+	dl "github.com/IBM/networking-go-sdk/directlinkv1"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/ibm/datamodel"
+)
+
+const directLinkNetworkType = "directlink"
+
+// Get all Direct Link gateways, with their virtual connections and route filters
+func getDirectLinkGateways(dlService *dl.DirectLinkV1, resourceGroupID string) ([]*datamodel.DirectLinkGateway, error) {
+	gatewayCollection, _, err := dlService.ListGateways(&dl.ListGatewaysOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("[getDirectLinkGateways] error getting Direct Link gateways: %w", err)
+	}
+
+	res := []*datamodel.DirectLinkGateway{}
+	for _, item := range gatewayCollection.Gateways {
+		gateway, ok := item.(*dl.GatewayCollectionGatewaysItem)
+		if !ok {
+			return nil, fmt.Errorf("[getDirectLinkGateways] unexpected gateway type %T", item)
+		}
+		if resourceGroupID != "" && (gateway.ResourceGroup == nil || *gateway.ResourceGroup.ID != resourceGroupID) {
+			continue
+		}
+		gatewayID := gateway.ID
+
+		virtualConnections, _, vcErr := dlService.ListGatewayVirtualConnections(&dl.ListGatewayVirtualConnectionsOptions{
+			GatewayID: gatewayID})
+		if vcErr != nil {
+			return nil, fmt.Errorf("[getDirectLinkGateways] error getting virtual connections for %s: %w", *gatewayID, vcErr)
+		}
+		exportFilters, _, filterErr := dlService.ListGatewayExportRouteFilters(&dl.ListGatewayExportRouteFiltersOptions{
+			GatewayID: gatewayID})
+		if filterErr != nil {
+			return nil, fmt.Errorf("[getDirectLinkGateways] error getting export route filters for %s: %w", *gatewayID, filterErr)
+		}
+		importFilters, _, filterErr := dlService.ListGatewayImportRouteFilters(&dl.ListGatewayImportRouteFiltersOptions{
+			GatewayID: gatewayID})
+		if filterErr != nil {
+			return nil, fmt.Errorf("[getDirectLinkGateways] error getting import route filters for %s: %w", *gatewayID, filterErr)
+		}
+		res = append(res, datamodel.NewDirectLinkGateway(gateway, virtualConnections.VirtualConnections,
+			exportFilters.ExportRouteFilters, importFilters.ImportRouteFilters))
+	}
+	return res, nil
+}
+
+// linkDirectLinkTransitConnections sets, for each Direct Link gateway, the IDs of the transit connections to it
+func linkDirectLinkTransitConnections(gateways []*datamodel.DirectLinkGateway, transitConnections []*datamodel.TransitConnection) {
+	gatewayByCRN := map[string]*datamodel.DirectLinkGateway{}
+	for _, gateway := range gateways {
+		gatewayByCRN[*gateway.Crn] = gateway
+	}
+	for _, connection := range transitConnections {
+		if connection.NetworkType == nil || *connection.NetworkType != directLinkNetworkType || connection.NetworkID == nil {
+			continue
+		}
+		if gateway, ok := gatewayByCRN[*connection.NetworkID]; ok {
+			gateway.TransitConnections = append(gateway.TransitConnections, *connection.ID)
+		}
+	}
+}
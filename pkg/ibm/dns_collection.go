@@ -0,0 +1,178 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/networking-go-sdk/dnssvcsv1"
+	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/ibm/datamodel"
+)
+
+// dnsServicesResourceID is the catalog ID of the DNS Services (dns-svcs) service, used to list its instances
+const dnsServicesResourceID = "b4ed8a30-936f-11e9-b289-1d079699cbe5"
+
+type HasGetNextOffset interface {
+	GetNextOffset() (*int64, error)
+}
+
+// iterateOffsetPagedAPI is like iteratePagedAPI, for APIs (e.g., DNS Services) which are paged by offset
+func iterateOffsetPagedAPI[T any, Q HasGetNextOffset](
+	apiFunc func(int64, *int64) (Q, any, error),
+	getArray func(Q) []T) ([]T, error) {
+	var offset *int64 = nil
+	res := make([]T, 0)
+	for {
+		collection, _, err := apiFunc(pageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("[iterateOffsetPagedAPI] error getting item: %w", err)
+		}
+		res = append(res, getArray(collection)...)
+		offset, err = collection.GetNextOffset()
+		if err != nil {
+			return nil, fmt.Errorf("[iterateOffsetPagedAPI] error getting next page: %w", err)
+		}
+		if offset == nil {
+			break
+		}
+	}
+	return res, nil
+}
+
+// Get all DNS Services instances with private zones permitted to the given VPCs, or custom resolvers located in the
+// given subnets. Only these zones and resolvers are kept
+func getDNSInstances(rcService *resourcecontrollerv2.ResourceControllerV2, dnsService *dnssvcsv1.DnsSvcsV1,
+	resourceGroupID string, vpcCRNs, subnetCRNs map[string]bool) ([]*datamodel.DNSInstance, error) {
+	instanceAPIFunc := func(pageSize int64, next *string) (*resourcecontrollerv2.ResourceInstancesList, any, error) {
+		options := &resourcecontrollerv2.ListResourceInstancesOptions{ResourceID: core.StringPtr(dnsServicesResourceID),
+			Limit: &pageSize, Start: next}
+		if resourceGroupID != "" {
+			options.ResourceGroupID = &resourceGroupID
+		}
+		return rcService.ListResourceInstances(options)
+	}
+	instanceGetArray := func(collection *resourcecontrollerv2.ResourceInstancesList) []resourcecontrollerv2.ResourceInstance {
+		return collection.Resources
+	}
+	instances, err := iteratePagedAPI(instanceAPIFunc, instanceGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getDNSInstances] error getting DNS Services instances: %w", err)
+	}
+
+	res := []*datamodel.DNSInstance{}
+	for i := range instances {
+		// The DNS Services API identifies instances by their GUID
+		instanceID := *instances[i].GUID
+		zones, zonesErr := getDNSZones(dnsService, instanceID, vpcCRNs)
+		if zonesErr != nil {
+			return nil, zonesErr
+		}
+		resolvers, resolversErr := getDNSCustomResolvers(dnsService, instanceID, subnetCRNs)
+		if resolversErr != nil {
+			return nil, resolversErr
+		}
+		if len(zones) == 0 && len(resolvers) == 0 {
+			continue
+		}
+		res = append(res, datamodel.NewDNSInstance(&instances[i], zones, resolvers))
+	}
+	return res, nil
+}
+
+// Get the private zones of a DNS Services instance which are permitted to any of the given VPCs, with their resource records
+func getDNSZones(dnsService *dnssvcsv1.DnsSvcsV1, instanceID string, vpcCRNs map[string]bool) ([]datamodel.DNSZone, error) {
+	zoneAPIFunc := func(pageSize int64, offset *int64) (*dnssvcsv1.ListDnszones, any, error) {
+		return dnsService.ListDnszones(&dnssvcsv1.ListDnszonesOptions{InstanceID: &instanceID, Limit: &pageSize, Offset: offset})
+	}
+	zoneGetArray := func(collection *dnssvcsv1.ListDnszones) []dnssvcsv1.Dnszone {
+		return collection.Dnszones
+	}
+	zones, err := iterateOffsetPagedAPI(zoneAPIFunc, zoneGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getDNSZones] error getting zones for %s: %w", instanceID, err)
+	}
+
+	res := []datamodel.DNSZone{}
+	for i := range zones {
+		zoneID := *zones[i].ID
+		permittedNetworks, _, pnErr := dnsService.ListPermittedNetworks(&dnssvcsv1.ListPermittedNetworksOptions{InstanceID: &instanceID,
+			DnszoneID: &zoneID})
+		if pnErr != nil {
+			return nil, fmt.Errorf("[getDNSZones] error getting permitted networks for %s: %w", zoneID, pnErr)
+		}
+		if !permitsAnyVPC(permittedNetworks.PermittedNetworks, vpcCRNs) {
+			continue
+		}
+
+		recordAPIFunc := func(pageSize int64, offset *int64) (*dnssvcsv1.ListResourceRecords, any, error) {
+			return dnsService.ListResourceRecords(&dnssvcsv1.ListResourceRecordsOptions{InstanceID: &instanceID, DnszoneID: &zoneID,
+				Limit: &pageSize, Offset: offset})
+		}
+		recordGetArray := func(collection *dnssvcsv1.ListResourceRecords) []dnssvcsv1.ResourceRecord {
+			return collection.ResourceRecords
+		}
+		records, recordsErr := iterateOffsetPagedAPI(recordAPIFunc, recordGetArray)
+		if recordsErr != nil {
+			return nil, fmt.Errorf("[getDNSZones] error getting resource records for %s: %w", zoneID, recordsErr)
+		}
+		res = append(res, datamodel.NewDNSZone(&zones[i], records, permittedNetworks.PermittedNetworks))
+	}
+	return res, nil
+}
+
+func permitsAnyVPC(permittedNetworks []dnssvcsv1.PermittedNetwork, vpcCRNs map[string]bool) bool {
+	for i := range permittedNetworks {
+		network := permittedNetworks[i].PermittedNetwork
+		if network != nil && network.VpcCrn != nil && vpcCRNs[*network.VpcCrn] {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the custom resolvers of a DNS Services instance located in any of the given subnets, with their forwarding rules
+func getDNSCustomResolvers(dnsService *dnssvcsv1.DnsSvcsV1, instanceID string,
+	subnetCRNs map[string]bool) ([]datamodel.DNSCustomResolver, error) {
+	resolvers, _, err := dnsService.ListCustomResolvers(&dnssvcsv1.ListCustomResolversOptions{InstanceID: &instanceID})
+	if err != nil {
+		return nil, fmt.Errorf("[getDNSCustomResolvers] error getting custom resolvers for %s: %w", instanceID, err)
+	}
+
+	res := []datamodel.DNSCustomResolver{}
+	for i := range resolvers.CustomResolvers {
+		resolver := &resolvers.CustomResolvers[i]
+		if !locatedInAnySubnet(resolver.Locations, subnetCRNs) {
+			continue
+		}
+		resolverID := *resolver.ID
+		ruleAPIFunc := func(pageSize int64, offset *int64) (*dnssvcsv1.ForwardingRuleList, any, error) {
+			return dnsService.ListForwardingRules(&dnssvcsv1.ListForwardingRulesOptions{InstanceID: &instanceID, ResolverID: &resolverID,
+				Limit: &pageSize, Offset: offset})
+		}
+		ruleGetArray := func(collection *dnssvcsv1.ForwardingRuleList) []dnssvcsv1.ForwardingRule {
+			return collection.ForwardingRules
+		}
+		rules, rulesErr := iterateOffsetPagedAPI(ruleAPIFunc, ruleGetArray)
+		if rulesErr != nil {
+			return nil, fmt.Errorf("[getDNSCustomResolvers] error getting forwarding rules for %s: %w", resolverID, rulesErr)
+		}
+		res = append(res, datamodel.NewDNSCustomResolver(resolver, rules))
+	}
+	return res, nil
+}
+
+func locatedInAnySubnet(locations []dnssvcsv1.Location, subnetCRNs map[string]bool) bool {
+	for i := range locations {
+		if locations[i].SubnetCrn != nil && subnetCRNs[*locations[i].SubnetCrn] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ibm
+
+import (
+	"github.com/np-guard/cloud-resource-collector/pkg/common"
+	"github.com/np-guard/cloud-resource-collector/pkg/tfstate"
+)
+
+// RegisterProvider registers the IBM provider
+func RegisterProvider() error {
+	return common.RegisterProvider(&common.ProviderInfo{
+		Name: common.IBM,
+		NewResourcesContainer: func(regions []string, resourceGroup string) common.ResourcesContainerInf {
+			return NewResourcesContainer(regions, resourceGroup)
+		},
+		AllRegions:             allRegions,
+		SupportsFabricate:      true,
+		SupportsResourceGroups: true,
+		FromTerraformState: func(state *tfstate.State) (common.ResourcesContainerInf, error) {
+			return FromTerraformState(state), nil
+		},
+		ExportTerraform: exportTerraform,
+	})
+}
@@ -15,8 +15,11 @@ import (
 
 	iksv1 "github.com/IBM-Cloud/container-services-go-sdk/kubernetesserviceapiv1"
 	"github.com/IBM/go-sdk-core/v5/core"
+	dl "github.com/IBM/networking-go-sdk/directlinkv1"
+	"github.com/IBM/networking-go-sdk/dnssvcsv1"
 	tgw "github.com/IBM/networking-go-sdk/transitgatewayapisv1"
 	"github.com/IBM/platform-services-go-sdk/globaltaggingv1"
+	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
 	"github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 
@@ -153,6 +156,13 @@ func (resources *ResourcesContainer) collectTags() error {
 		}
 	}
 
+	for i := range resources.BareMetalServerList {
+		err := tagsCollector.setResourceTags(resources.BareMetalServerList[i])
+		if err != nil {
+			return err
+		}
+	}
+
 	for i := range resources.VirtualNIList {
 		err := tagsCollector.setResourceTags(resources.VirtualNIList[i])
 		if err != nil {
@@ -167,6 +177,48 @@ func (resources *ResourcesContainer) collectTags() error {
 		}
 	}
 
+	for i := range resources.PrivatePathSGList {
+		err := tagsCollector.setResourceTags(resources.PrivatePathSGList[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range resources.FlowLogCollectorList {
+		err := tagsCollector.setResourceTags(resources.FlowLogCollectorList[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range resources.VPNGatewayList {
+		err := tagsCollector.setResourceTags(resources.VPNGatewayList[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range resources.VPNServerList {
+		err := tagsCollector.setResourceTags(resources.VPNServerList[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range resources.DirectLinkGatewayList {
+		err := tagsCollector.setResourceTags(resources.DirectLinkGatewayList[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range resources.DNSInstanceList {
+		err := tagsCollector.setResourceTags(resources.DNSInstanceList[i])
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -240,7 +292,13 @@ func (resources *ResourcesContainer) CollectResourcesFromAPI() error {
 		return err
 	}
 
-	return nil
+	err = resources.collectDNSResources(apiKey)
+	if err != nil {
+		return err
+	}
+
+	// Add the tags to all (taggable) resources
+	return resources.collectTags()
 }
 
 //nolint:funlen // function is long because there are many types of resources we collect
@@ -317,6 +375,48 @@ func (resources *ResourcesContainer) collectRegionalResources(region, apiKey str
 	}
 	resources.EndpointGWList = append(resources.EndpointGWList, vpes...)
 
+	// Instances, bare metal servers and virtual network interfaces
+	if err = resources.collectComputeResources(vpcService); err != nil {
+		return err
+	}
+
+	// Routing Tables
+	rts, err := getRoutingTables(vpcService, vpcs)
+	if err != nil {
+		return err
+	}
+	resources.RoutingTableList = append(resources.RoutingTableList, rts...)
+
+	// Load Balancers
+	lbs, err := getLoadBalancers(vpcService, resources.resourceGroupID)
+	if err != nil {
+		return err
+	}
+	resources.LBList = append(resources.LBList, lbs...)
+
+	// Private Path Service Gateways, and the load balancers backing them, which were not collected above
+	ppsgs, err := getPrivatePathServiceGateways(vpcService, resources.resourceGroupID)
+	if err != nil {
+		return err
+	}
+	resources.PrivatePathSGList = append(resources.PrivatePathSGList, ppsgs...)
+	ppsgLBs, err := getPrivatePathLoadBalancers(vpcService, ppsgs, lbs)
+	if err != nil {
+		return err
+	}
+	resources.LBList = append(resources.LBList, ppsgLBs...)
+
+	// Flow Log Collectors
+	flowLogCollectors, err := getFlowLogCollectors(vpcService, resources.resourceGroupID)
+	if err != nil {
+		return err
+	}
+	resources.FlowLogCollectorList = append(resources.FlowLogCollectorList, flowLogCollectors...)
+	return resources.collectVPNResources(vpcService)
+}
+
+// collectComputeResources collects the instances and bare metal servers of a region, and its virtual network interfaces
+func (resources *ResourcesContainer) collectComputeResources(vpcService *vpcv1.VpcV1) error {
 	// Instances
 	insts, err := getInstances(vpcService, resources.resourceGroupID)
 	if err != nil {
@@ -324,25 +424,55 @@ func (resources *ResourcesContainer) collectRegionalResources(region, apiKey str
 	}
 	resources.InstanceList = append(resources.InstanceList, insts...)
 
+	// Bare Metal Servers
+	bareMetalServers, err := getBareMetalServers(vpcService, resources.resourceGroupID)
+	if err != nil {
+		return err
+	}
+	resources.BareMetalServerList = append(resources.BareMetalServerList, bareMetalServers...)
+
 	vnis, err := getVirtualNIs(vpcService, resources.resourceGroupID)
 	if err != nil {
 		return err
 	}
 	resources.VirtualNIList = append(resources.VirtualNIList, vnis...)
 
-	// Routing Tables
-	rts, err := getRoutingTables(vpcService, vpcs)
+	// Virtual network interfaces attached to the instances, which were not collected above
+	attachedVNIs, err := getAttachedVirtualNIs(vpcService, insts, vnis)
 	if err != nil {
 		return err
 	}
-	resources.RoutingTableList = append(resources.RoutingTableList, rts...)
+	resources.VirtualNIList = append(resources.VirtualNIList, attachedVNIs...)
+	return nil
+}
 
-	// Load Balancers
-	lbs, err := getLoadBalancers(vpcService, resources.resourceGroupID)
+// collectVPNResources collects the VPN gateways of a region, with their connections, the IKE and IPsec policies,
+// and the client-to-site VPN servers
+func (resources *ResourcesContainer) collectVPNResources(vpcService *vpcv1.VpcV1) error {
+	vpnGateways, err := getVPNGateways(vpcService, resources.resourceGroupID)
 	if err != nil {
 		return err
 	}
-	resources.LBList = append(resources.LBList, lbs...)
+	resources.VPNGatewayList = append(resources.VPNGatewayList, vpnGateways...)
+
+	ikePolicies, err := getIKEPolicies(vpcService, resources.resourceGroupID)
+	if err != nil {
+		return err
+	}
+	resources.IKEPolicyList = append(resources.IKEPolicyList, ikePolicies...)
+
+	ipsecPolicies, err := getIPsecPolicies(vpcService, resources.resourceGroupID)
+	if err != nil {
+		return err
+	}
+	resources.IPsecPolicyList = append(resources.IPsecPolicyList, ipsecPolicies...)
+
+	// Client-to-site VPN Servers
+	vpnServers, err := getVPNServers(vpcService, resources.resourceGroupID)
+	if err != nil {
+		return err
+	}
+	resources.VPNServerList = append(resources.VPNServerList, vpnServers...)
 	return nil
 }
 
@@ -377,6 +507,14 @@ func (resources *ResourcesContainer) collectGlobalResources(apiKey string) error
 		return err
 	}
 
+	// Direct Link gateways. Failing to collect them (e.g., when the account has no access to Direct Link) does not fail
+	// the collection
+	err = resources.collectDirectLinkGateways(apiKey)
+	if err != nil {
+		log.Printf("Direct Link gateways will not be collected: %v\n", err)
+		resources.DirectLinkGatewayList = []*datamodel.DirectLinkGateway{}
+	}
+
 	// Instantiate the IKS service with an API key based IAM authenticator
 	iksService, err := iksv1.NewKubernetesServiceApiV1(&iksv1.KubernetesServiceApiV1Options{
 		Authenticator: &core.IamAuthenticator{
@@ -394,11 +532,66 @@ func (resources *ResourcesContainer) collectGlobalResources(apiKey string) error
 	}
 	resources.IKSClusters = append(resources.IKSClusters, clusters...)
 
-	// Add the tags to all (taggable) resources
-	err = resources.collectTags()
+	return nil
+}
+
+// collectDirectLinkGateways collects the Direct Link gateways, with the transit connections to them
+func (resources *ResourcesContainer) collectDirectLinkGateways(apiKey string) error {
+	// Instantiate the Direct Link service with an API key based IAM authenticator
+	var dlServiceVersion = "2023-12-12"
+	dlService, err := dl.NewDirectLinkV1(&dl.DirectLinkV1Options{
+		Version: &dlServiceVersion,
+		Authenticator: &core.IamAuthenticator{
+			ApiKey: apiKey,
+		},
+	})
 	if err != nil {
-		return err
+		return errors.New("error creating Direct Link Service")
 	}
 
+	resources.DirectLinkGatewayList, err = getDirectLinkGateways(dlService, resources.resourceGroupID)
+	if err != nil {
+		return err
+	}
+	linkDirectLinkTransitConnections(resources.DirectLinkGatewayList, resources.TransitConnectionList)
 	return nil
 }
+
+// collectDNSResources collects the DNS Services instances serving the collected VPCs, with their private zones (resource
+// records and permitted networks) and custom resolvers (forwarding rules)
+func (resources *ResourcesContainer) collectDNSResources(apiKey string) error {
+	log.Println("Collecting DNS Services resources")
+
+	vpcCRNs := map[string]bool{}
+	for _, vpc := range resources.VpcList {
+		vpcCRNs[*vpc.CRN] = true
+	}
+	subnetCRNs := map[string]bool{}
+	for _, subnet := range resources.SubnetList {
+		subnetCRNs[*subnet.CRN] = true
+	}
+	if len(vpcCRNs) == 0 {
+		return nil // DNS resources are only collected for the VPCs in the snapshot
+	}
+
+	// Instantiate the Resource Controller and DNS Services with an API key based IAM authenticator
+	rcService, err := resourcecontrollerv2.NewResourceControllerV2(&resourcecontrollerv2.ResourceControllerV2Options{
+		Authenticator: &core.IamAuthenticator{
+			ApiKey: apiKey,
+		},
+	})
+	if err != nil {
+		return errors.New("error creating Resource Controller Service")
+	}
+	dnsService, err := dnssvcsv1.NewDnsSvcsV1(&dnssvcsv1.DnsSvcsV1Options{
+		Authenticator: &core.IamAuthenticator{
+			ApiKey: apiKey,
+		},
+	})
+	if err != nil {
+		return errors.New("error creating DNS Services Service")
+	}
+
+	resources.DNSInstanceList, err = getDNSInstances(rcService, dnsService, resources.resourceGroupID, vpcCRNs, subnetCRNs)
+	return err
+}
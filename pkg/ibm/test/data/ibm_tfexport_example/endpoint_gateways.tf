@@ -0,0 +1,19 @@
+resource "ibm_is_virtual_endpoint_gateway" "cos" {
+  provider        = ibm.us_south
+  name            = "cos"
+  vpc             = ibm_is_vpc.main.id
+  security_groups = [ibm_is_security_group.db.id]
+  tags            = ["service:cos"]
+  target {
+    crn           = "crn:v1:bluemix:public:cloud-object-storage:global:::endpoint:s3.direct.us-south.cloud-object-storage.appdomain.cloud"
+    resource_type = "provider_cloud_service"
+  }
+  ips {
+    subnet = ibm_is_subnet.public.id
+    name   = "cos-public"
+  }
+  ips {
+    subnet = ibm_is_subnet.private.id
+    name   = "cos-private"
+  }
+}
@@ -0,0 +1,51 @@
+resource "ibm_is_network_acl" "public-acl" {
+  provider = ibm.us_south
+  name     = "public-acl"
+  vpc      = ibm_is_vpc.main.id
+  rules {
+    name        = "allow-https"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/24"
+    tcp {
+      port_min        = 443
+      port_max        = 443
+      source_port_min = 1
+      source_port_max = 65535
+    }
+  }
+  rules {
+    name        = "allow-ping"
+    action      = "allow"
+    direction   = "inbound"
+    source      = "0.0.0.0/0"
+    destination = "0.0.0.0/0"
+    icmp {
+      type = 8
+    }
+  }
+  rules {
+    name        = "allow-icmp-outbound"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "0.0.0.0/0"
+    destination = "10.240.0.0/24"
+    icmp {
+    }
+  }
+  rules {
+    name        = "allow-outbound"
+    action      = "allow"
+    direction   = "outbound"
+    source      = "10.240.0.0/24"
+    destination = "0.0.0.0/0"
+  }
+  rules {
+    name        = "deny-inbound"
+    action      = "deny"
+    direction   = "inbound"
+    source      = "0.0.0.0/0"
+    destination = "0.0.0.0/0"
+  }
+}
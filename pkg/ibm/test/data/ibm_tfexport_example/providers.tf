@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    ibm = {
+      source = "IBM-Cloud/ibm"
+    }
+  }
+}
+
+provider "ibm" {
+  region = "eu-de"
+}
+
+provider "ibm" {
+  alias  = "eu_de"
+  region = "eu-de"
+}
+
+provider "ibm" {
+  alias  = "us_south"
+  region = "us-south"
+}
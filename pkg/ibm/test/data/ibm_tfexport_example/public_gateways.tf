@@ -0,0 +1,6 @@
+resource "ibm_is_public_gateway" "pgw" {
+  provider = ibm.us_south
+  name     = "pgw"
+  vpc      = ibm_is_vpc.main.id
+  zone     = "us-south-1"
+}
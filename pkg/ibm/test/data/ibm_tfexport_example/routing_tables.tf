@@ -0,0 +1,43 @@
+resource "ibm_is_vpc_routing_table_route" "to-firewall" {
+  provider      = ibm.us_south
+  name          = "to-firewall"
+  vpc           = ibm_is_vpc.main.id
+  routing_table = ibm_is_vpc.main.default_routing_table
+  zone          = "us-south-1"
+  destination   = "10.10.0.0/16"
+  action        = "deliver"
+  next_hop      = "10.240.0.4"
+  priority      = 2
+}
+
+resource "ibm_is_vpc_routing_table" "egress" {
+  provider                  = ibm.us_south
+  name                      = "egress"
+  vpc                       = ibm_is_vpc.main.id
+  route_direct_link_ingress = true
+  route_vpc_zone_ingress    = true
+}
+
+resource "ibm_is_vpc_routing_table_route" "block-metadata" {
+  provider      = ibm.us_south
+  name          = "block-metadata"
+  vpc           = ibm_is_vpc.main.id
+  routing_table = ibm_is_vpc_routing_table.egress.routing_table
+  zone          = "us-south-1"
+  destination   = "169.254.0.0/16"
+  action        = "drop"
+  next_hop      = "0.0.0.0"
+  priority      = 0
+}
+
+resource "ibm_is_vpc_routing_table_route" "internet" {
+  provider      = ibm.us_south
+  name          = "internet"
+  vpc           = ibm_is_vpc.main.id
+  routing_table = ibm_is_vpc_routing_table.egress.routing_table
+  zone          = "us-south-1"
+  destination   = "0.0.0.0/0"
+  action        = "delegate"
+  next_hop      = "0.0.0.0"
+  priority      = 2
+}
@@ -0,0 +1,63 @@
+resource "ibm_is_security_group" "web" {
+  provider = ibm.us_south
+  name     = "web"
+  vpc      = ibm_is_vpc.main.id
+}
+
+resource "ibm_is_security_group" "db" {
+  provider = ibm.us_south
+  name     = "db"
+  vpc      = ibm_is_vpc.main.id
+}
+
+resource "ibm_is_security_group_rule" "web-rule-1" {
+  provider  = ibm.us_south
+  group     = ibm_is_security_group.web.id
+  direction = "inbound"
+  remote    = "0.0.0.0/0"
+  tcp {
+    port_min = 443
+    port_max = 443
+  }
+}
+
+resource "ibm_is_security_group_rule" "web-rule-2" {
+  provider  = ibm.us_south
+  group     = ibm_is_security_group.web.id
+  direction = "outbound"
+  remote    = ibm_is_security_group.db.id
+}
+
+resource "ibm_is_security_group_rule" "web-rule-3" {
+  provider  = ibm.us_south
+  group     = ibm_is_security_group.web.id
+  direction = "inbound"
+  remote    = "0.0.0.0/0"
+  icmp {
+    type = 0
+    code = 0
+  }
+}
+
+resource "ibm_is_security_group_rule" "db-rule-1" {
+  provider  = ibm.us_south
+  group     = ibm_is_security_group.db.id
+  direction = "inbound"
+  remote    = ibm_is_security_group.web.id
+  tcp {
+    port_min = 5432
+    port_max = 5432
+  }
+}
+
+resource "ibm_is_security_group_rule" "db-rule-2" {
+  provider  = ibm.us_south
+  group     = ibm_is_security_group.db.id
+  direction = "inbound"
+  remote    = "10.240.0.4"
+  local     = "10.240.0.5"
+  icmp {
+    type = 8
+    code = 0
+  }
+}
@@ -0,0 +1,31 @@
+resource "ibm_is_subnet" "public" {
+  provider        = ibm.us_south
+  name            = "public"
+  vpc             = ibm_is_vpc.main.id
+  zone            = "us-south-1"
+  ipv4_cidr_block = "10.240.0.0/24"
+  network_acl     = ibm_is_network_acl.public-acl.id
+  public_gateway  = ibm_is_public_gateway.pgw.id
+  tags            = ["tier:public"]
+  depends_on      = [ibm_is_vpc_address_prefix.main-us-south-1, ibm_is_vpc_address_prefix.main-us-south-2, ibm_is_vpc_address_prefix.extra]
+}
+
+resource "ibm_is_subnet" "private" {
+  provider        = ibm.us_south
+  name            = "private"
+  vpc             = ibm_is_vpc.main.id
+  zone            = "us-south-1"
+  ipv4_cidr_block = "192.168.0.0/24"
+  network_acl     = ibm_is_network_acl.public-acl.id
+  routing_table   = ibm_is_vpc_routing_table.egress.routing_table
+  depends_on      = [ibm_is_vpc_address_prefix.main-us-south-1, ibm_is_vpc_address_prefix.main-us-south-2, ibm_is_vpc_address_prefix.extra]
+}
+
+resource "ibm_is_subnet" "edge" {
+  provider        = ibm.eu_de
+  name            = "edge"
+  vpc             = ibm_is_vpc.edge.id
+  zone            = "eu-de-1"
+  ipv4_cidr_block = "10.243.0.0/24"
+  depends_on      = [ibm_is_vpc_address_prefix.edge-prefix]
+}
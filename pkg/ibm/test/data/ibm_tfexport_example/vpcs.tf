@@ -0,0 +1,46 @@
+resource "ibm_is_vpc" "main" {
+  provider                  = ibm.us_south
+  name                      = "main"
+  address_prefix_management = "manual"
+  tags                      = ["env:test"]
+}
+
+resource "ibm_is_vpc_address_prefix" "main-us-south-1" {
+  provider   = ibm.us_south
+  name       = "main-us-south-1"
+  vpc        = ibm_is_vpc.main.id
+  zone       = "us-south-1"
+  cidr       = "10.240.0.0/18"
+  is_default = true
+}
+
+resource "ibm_is_vpc_address_prefix" "main-us-south-2" {
+  provider   = ibm.us_south
+  name       = "main-us-south-2"
+  vpc        = ibm_is_vpc.main.id
+  zone       = "us-south-2"
+  cidr       = "10.240.64.0/18"
+  is_default = true
+}
+
+resource "ibm_is_vpc_address_prefix" "extra" {
+  provider = ibm.us_south
+  name     = "extra"
+  vpc      = ibm_is_vpc.main.id
+  zone     = "us-south-1"
+  cidr     = "192.168.0.0/24"
+}
+
+resource "ibm_is_vpc" "edge" {
+  provider                  = ibm.eu_de
+  name                      = "edge"
+  address_prefix_management = "manual"
+}
+
+resource "ibm_is_vpc_address_prefix" "edge-prefix" {
+  provider = ibm.eu_de
+  name     = "edge-prefix"
+  vpc      = ibm_is_vpc.edge.id
+  zone     = "eu-de-1"
+  cidr     = "10.243.0.0/18"
+}
@@ -0,0 +1,403 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ibm
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/netip"
+	"slices"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/ibm/datamodel"
+	"github.com/np-guard/cloud-resource-collector/pkg/tfexport"
+)
+
+// Terraform resource types generated by ExportTerraform, in addition to those translated by FromTerraformState
+const (
+	tfVPCRoutingTable        = "ibm_is_vpc_routing_table"
+	tfVPCRoutingTableRoute   = "ibm_is_vpc_routing_table_route"
+	tfVirtualEndpointGateway = "ibm_is_virtual_endpoint_gateway"
+)
+
+// Files of the generated configuration
+const (
+	tfProviderName         = "ibm"
+	tfProviderSource       = "IBM-Cloud/ibm"
+	tfVPCsFile             = "vpcs"
+	tfSubnetsFile          = "subnets"
+	tfPublicGatewaysFile   = "public_gateways"
+	tfNetworkACLsFile      = "network_acls"
+	tfSecurityGroupsFile   = "security_groups"
+	tfRoutingTablesFile    = "routing_tables"
+	tfEndpointGatewaysFile = "endpoint_gateways"
+)
+
+const (
+	userRouteOrigin = "user"
+	anyIPv4Address  = "0.0.0.0"
+)
+
+// tfExporter generates the Terraform resources recreating the VPC resources of a snapshot.
+// Resources reference each other by their IDs in the snapshot, which the configuration maps to Terraform references
+type tfExporter struct {
+	config    *tfexport.Config
+	resources *datamodel.ResourcesContainerModel
+	// the region of each VPC, and the VPC of each default routing table
+	vpcRegions    map[string]string
+	defaultRTVPCs map[string]string
+	// the address prefixes exported for each VPC (the subnets of a VPC depend on these)
+	vpcPrefixes map[string][]string
+}
+
+// ExportTerraform generates a Terraform configuration recreating the VPCs in the given resources, with their address
+// prefixes, subnets, public gateways, network ACLs, security groups, routing tables and endpoint gateways.
+// Default ACLs and security groups are recreated as regular ones, and subnets are attached to them explicitly.
+// Other resources (e.g., instances and load balancers) are not exported
+func ExportTerraform(resources *datamodel.ResourcesContainerModel) *tfexport.Config {
+	e := &tfExporter{config: tfexport.NewConfig(), resources: resources, vpcRegions: map[string]string{},
+		defaultRTVPCs: map[string]string{}, vpcPrefixes: map[string][]string{}}
+	regions := []string{}
+	for _, vpc := range resources.VpcList {
+		e.vpcRegions[*vpc.ID] = vpc.Region
+		if vpc.DefaultRoutingTable != nil && vpc.DefaultRoutingTable.ID != nil {
+			e.defaultRTVPCs[*vpc.DefaultRoutingTable.ID] = *vpc.ID
+		}
+		if vpc.Region != "" && !slices.Contains(regions, vpc.Region) {
+			regions = append(regions, vpc.Region)
+		}
+	}
+	slices.Sort(regions)
+	e.config.AddProvider(tfProviderName, tfProviderSource, regions)
+
+	// resources are exported after the resources they reference
+	e.exportVPCs()
+	e.exportPublicGateways()
+	e.exportNetworkACLs()
+	e.exportSecurityGroups()
+	e.exportRoutingTables()
+	e.exportSubnets()
+	e.exportEndpointGateways()
+	return e.config
+}
+
+// exportTerraform generates a Terraform configuration from the content of an IBM snapshot
+func exportTerraform(snapshot []byte) (*tfexport.Config, error) {
+	resources := datamodel.NewResourcesContainerModel()
+	if err := json.Unmarshal(snapshot, resources); err != nil {
+		return nil, fmt.Errorf("[exportTerraform] error parsing IBM snapshot: %w", err)
+	}
+	return ExportTerraform(resources), nil
+}
+
+func (e *tfExporter) exportVPCs() {
+	for _, vpc := range e.resources.VpcList {
+		body := e.config.AddResource(tfVPCsFile, tfVPC, *vpc.Name, *vpc.ID, vpc.Region)
+		body.SetAttributeValue("name", cty.StringVal(*vpc.Name))
+		body.SetAttributeValue("address_prefix_management", cty.StringVal("manual"))
+		tfexport.SetStrings(body, "tags", vpc.Tags)
+		e.exportAddressPrefixes(vpc)
+	}
+}
+
+// exportAddressPrefixes exports the address prefixes of a VPC. Snapshots without address prefixes (e.g., fabricated
+// ones) get a prefix for each subnet, so that the subnets can be created
+func (e *tfExporter) exportAddressPrefixes(vpc *datamodel.VPC) {
+	prefixes := vpc.AddressPrefixes
+	if len(prefixes) == 0 {
+		for _, subnet := range e.resources.SubnetList {
+			if subnet.VPC != nil && *subnet.VPC.ID == *vpc.ID && subnet.Ipv4CIDRBlock != nil {
+				prefixes = append(prefixes, vpcv1.AddressPrefix{Name: core.StringPtr(*subnet.Name + "-prefix"),
+					CIDR: subnet.Ipv4CIDRBlock, Zone: subnet.Zone})
+			}
+		}
+	}
+	for i := range prefixes {
+		prefix := &prefixes[i]
+		name := *vpc.Name + "-" + *prefix.Zone.Name // the name of default prefixes is not collected
+		if prefix.Name != nil {
+			name = *prefix.Name
+		}
+		prefixID := fmt.Sprintf("%s/address_prefix/%d", *vpc.ID, i)
+		body := e.config.AddResource(tfVPCsFile, tfVPCAddressPrefix, name, prefixID, vpc.Region)
+		body.SetAttributeValue("name", cty.StringVal(name))
+		body.SetAttributeRaw("vpc", e.config.Ref(*vpc.ID, "id"))
+		body.SetAttributeValue("zone", cty.StringVal(*prefix.Zone.Name))
+		body.SetAttributeValue("cidr", cty.StringVal(*prefix.CIDR))
+		if prefix.IsDefault != nil && *prefix.IsDefault {
+			body.SetAttributeValue("is_default", cty.True)
+		}
+		e.vpcPrefixes[*vpc.ID] = append(e.vpcPrefixes[*vpc.ID], prefixID)
+	}
+}
+
+func (e *tfExporter) exportPublicGateways() {
+	for _, pgw := range e.resources.PublicGWList {
+		body := e.config.AddResource(tfPublicGatewaysFile, tfPublicGateway, *pgw.Name, *pgw.ID, e.vpcRegions[*pgw.VPC.ID])
+		body.SetAttributeValue("name", cty.StringVal(*pgw.Name))
+		body.SetAttributeRaw("vpc", e.config.Ref(*pgw.VPC.ID, "id"))
+		body.SetAttributeValue("zone", cty.StringVal(*pgw.Zone.Name))
+		tfexport.SetStrings(body, "tags", pgw.Tags)
+	}
+}
+
+func (e *tfExporter) exportNetworkACLs() {
+	for _, acl := range e.resources.NetworkACLList {
+		body := e.config.AddResource(tfNetworkACLsFile, tfNetworkACL, *acl.Name, *acl.ID, e.vpcRegions[*acl.VPC.ID])
+		body.SetAttributeValue("name", cty.StringVal(*acl.Name))
+		body.SetAttributeRaw("vpc", e.config.Ref(*acl.VPC.ID, "id"))
+		tfexport.SetStrings(body, "tags", acl.Tags)
+		for _, rule := range acl.Rules {
+			exportNetworkACLRule(body.AppendNewBlock("rules", nil).Body(), rule)
+		}
+	}
+}
+
+func exportNetworkACLRule(body *hclwrite.Body, rule vpcv1.NetworkACLRuleItemIntf) {
+	switch rule := rule.(type) {
+	case *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAll:
+		setNetworkACLRuleAttributes(body, rule.Name, rule.Action, rule.Direction, rule.Source, rule.Destination)
+	case *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolIcmp:
+		setNetworkACLRuleAttributes(body, rule.Name, rule.Action, rule.Direction, rule.Source, rule.Destination)
+		icmp := body.AppendNewBlock(*rule.Protocol, nil).Body()
+		tfexport.SetInt(icmp, "type", rule.Type)
+		tfexport.SetInt(icmp, "code", rule.Code)
+	case *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolTcpudp:
+		setNetworkACLRuleAttributes(body, rule.Name, rule.Action, rule.Direction, rule.Source, rule.Destination)
+		ports := body.AppendNewBlock(*rule.Protocol, nil).Body()
+		tfexport.SetInt(ports, "port_min", rule.DestinationPortMin)
+		tfexport.SetInt(ports, "port_max", rule.DestinationPortMax)
+		tfexport.SetInt(ports, "source_port_min", rule.SourcePortMin)
+		tfexport.SetInt(ports, "source_port_max", rule.SourcePortMax)
+	}
+}
+
+func setNetworkACLRuleAttributes(body *hclwrite.Body, name, action, direction, source, destination *string) {
+	tfexport.SetString(body, "name", name)
+	tfexport.SetString(body, "action", action)
+	tfexport.SetString(body, "direction", direction)
+	tfexport.SetString(body, "source", source)
+	tfexport.SetString(body, "destination", destination)
+}
+
+// exportSecurityGroups exports all security groups before their rules, as rules may reference other groups
+func (e *tfExporter) exportSecurityGroups() {
+	for _, sg := range e.resources.SecurityGroupList {
+		body := e.config.AddResource(tfSecurityGroupsFile, tfSecurityGroup, *sg.Name, *sg.ID, e.vpcRegions[*sg.VPC.ID])
+		body.SetAttributeValue("name", cty.StringVal(*sg.Name))
+		body.SetAttributeRaw("vpc", e.config.Ref(*sg.VPC.ID, "id"))
+		tfexport.SetStrings(body, "tags", sg.Tags)
+	}
+	for _, sg := range e.resources.SecurityGroupList {
+		for i, rule := range sg.Rules {
+			name := fmt.Sprintf("%s-rule-%d", *sg.Name, i+1)
+			body := e.config.AddResource(tfSecurityGroupsFile, tfSecurityGroupRule, name, "", e.vpcRegions[*sg.VPC.ID])
+			body.SetAttributeRaw("group", e.config.Ref(*sg.ID, "id"))
+			e.exportSecurityGroupRule(body, rule)
+		}
+	}
+}
+
+func (e *tfExporter) exportSecurityGroupRule(body *hclwrite.Body, rule vpcv1.SecurityGroupRuleIntf) {
+	switch rule := rule.(type) {
+	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll:
+		e.setSecurityGroupRuleAttributes(body, rule.Direction, rule.Remote, rule.Local)
+	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolIcmp:
+		e.setSecurityGroupRuleAttributes(body, rule.Direction, rule.Remote, rule.Local)
+		icmp := body.AppendNewBlock(*rule.Protocol, nil).Body()
+		tfexport.SetInt(icmp, "type", rule.Type)
+		tfexport.SetInt(icmp, "code", rule.Code)
+	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp:
+		e.setSecurityGroupRuleAttributes(body, rule.Direction, rule.Remote, rule.Local)
+		ports := body.AppendNewBlock(*rule.Protocol, nil).Body()
+		tfexport.SetInt(ports, "port_min", rule.PortMin)
+		tfexport.SetInt(ports, "port_max", rule.PortMax)
+	}
+}
+
+// setSecurityGroupRuleAttributes sets the direction of a rule, its remote (a security group reference, a CIDR or an
+// IP address), and its local IP addresses, unless these are all addresses
+func (e *tfExporter) setSecurityGroupRuleAttributes(body *hclwrite.Body, direction *string,
+	remote vpcv1.SecurityGroupRuleRemoteIntf, local vpcv1.SecurityGroupRuleLocalIntf) {
+	tfexport.SetString(body, "direction", direction)
+	var remoteSG, remoteAddress *string
+	switch remote := remote.(type) {
+	case *vpcv1.SecurityGroupRuleRemote:
+		remoteSG, remoteAddress = remote.ID, firstSet(remote.CIDRBlock, remote.Address)
+	case *vpcv1.SecurityGroupRuleRemoteSecurityGroupReference:
+		remoteSG = remote.ID
+	case *vpcv1.SecurityGroupRuleRemoteCIDR:
+		remoteAddress = remote.CIDRBlock
+	case *vpcv1.SecurityGroupRuleRemoteIP:
+		remoteAddress = remote.Address
+	}
+	if remoteSG != nil {
+		body.SetAttributeRaw("remote", e.config.Ref(*remoteSG, "id"))
+	} else {
+		tfexport.SetString(body, "remote", remoteAddress)
+	}
+
+	var localAddress *string
+	switch local := local.(type) {
+	case *vpcv1.SecurityGroupRuleLocal:
+		localAddress = firstSet(local.CIDRBlock, local.Address)
+	case *vpcv1.SecurityGroupRuleLocalCIDR:
+		localAddress = local.CIDRBlock
+	case *vpcv1.SecurityGroupRuleLocalIP:
+		localAddress = local.Address
+	}
+	if localAddress != nil && *localAddress != anyIPv4 {
+		tfexport.SetString(body, "local", localAddress)
+	}
+}
+
+// exportRoutingTables exports the routing tables of all VPCs, with their user-defined routes.
+// The routes of a default routing table are added to the default routing table of the exported VPC
+func (e *tfExporter) exportRoutingTables() {
+	for _, rt := range e.resources.RoutingTableList {
+		vpcID := *rt.VPC.ID
+		region := e.vpcRegions[vpcID]
+		rtRef := e.config.Ref(vpcID, "default_routing_table")
+		if !e.isDefaultRoutingTable(rt) {
+			body := e.config.AddResource(tfRoutingTablesFile, tfVPCRoutingTable, *rt.Name, *rt.ID, region)
+			body.SetAttributeValue("name", cty.StringVal(*rt.Name))
+			body.SetAttributeRaw("vpc", e.config.Ref(vpcID, "id"))
+			setTrue(body, "route_direct_link_ingress", rt.RouteDirectLinkIngress)
+			setTrue(body, "route_internet_ingress", rt.RouteInternetIngress)
+			setTrue(body, "route_transit_gateway_ingress", rt.RouteTransitGatewayIngress)
+			setTrue(body, "route_vpc_zone_ingress", rt.RouteVPCZoneIngress)
+			rtRef = e.config.Ref(*rt.ID, "routing_table")
+		}
+		for i := range rt.Routes {
+			e.exportRoute(&rt.Routes[i].Route, vpcID, rtRef, region)
+		}
+	}
+}
+
+func (e *tfExporter) exportRoute(route *vpcv1.Route, vpcID string, rtRef hclwrite.Tokens, region string) {
+	if route.Origin != nil && *route.Origin != userRouteOrigin {
+		return // routes created by services are recreated by these services
+	}
+	nextHop := anyIPv4Address // the next hop of delegate and drop routes
+	if route.Action != nil && *route.Action == vpcv1.RouteActionDeliverConst {
+		hop, ok := route.NextHop.(*vpcv1.RouteNextHop)
+		if !ok || hop.Address == nil {
+			log.Printf("Skipping route %s: only routes with an IP address as next hop are exported", *route.Name)
+			return
+		}
+		nextHop = *hop.Address
+	}
+	body := e.config.AddResource(tfRoutingTablesFile, tfVPCRoutingTableRoute, *route.Name, "", region)
+	body.SetAttributeValue("name", cty.StringVal(*route.Name))
+	body.SetAttributeRaw("vpc", e.config.Ref(vpcID, "id"))
+	body.SetAttributeRaw("routing_table", rtRef)
+	body.SetAttributeValue("zone", cty.StringVal(*route.Zone.Name))
+	body.SetAttributeValue("destination", cty.StringVal(*route.Destination))
+	tfexport.SetString(body, "action", route.Action)
+	body.SetAttributeValue("next_hop", cty.StringVal(nextHop))
+	tfexport.SetInt(body, "priority", route.Priority)
+}
+
+func (e *tfExporter) isDefaultRoutingTable(rt *datamodel.RoutingTable) bool {
+	_, ok := e.defaultRTVPCs[*rt.ID]
+	return ok || rt.IsDefault != nil && *rt.IsDefault
+}
+
+func (e *tfExporter) exportSubnets() {
+	for _, subnet := range e.resources.SubnetList {
+		vpcID := *subnet.VPC.ID
+		body := e.config.AddResource(tfSubnetsFile, tfSubnet, *subnet.Name, *subnet.ID, e.vpcRegions[vpcID])
+		body.SetAttributeValue("name", cty.StringVal(*subnet.Name))
+		body.SetAttributeRaw("vpc", e.config.Ref(vpcID, "id"))
+		body.SetAttributeValue("zone", cty.StringVal(*subnet.Zone.Name))
+		tfexport.SetString(body, "ipv4_cidr_block", subnet.Ipv4CIDRBlock)
+		if subnet.NetworkACL != nil {
+			body.SetAttributeRaw("network_acl", e.config.Ref(*subnet.NetworkACL.ID, "id"))
+		}
+		if subnet.PublicGateway != nil {
+			body.SetAttributeRaw("public_gateway", e.config.Ref(*subnet.PublicGateway.ID, "id"))
+		}
+		if subnet.RoutingTable != nil && e.config.Exported(*subnet.RoutingTable.ID) {
+			body.SetAttributeRaw("routing_table", e.config.Ref(*subnet.RoutingTable.ID, "routing_table"))
+		}
+		tfexport.SetStrings(body, "tags", subnet.Tags)
+		if prefixes := e.vpcPrefixes[vpcID]; len(prefixes) > 0 {
+			body.SetAttributeRaw("depends_on", e.config.Refs(prefixes, ""))
+		}
+	}
+}
+
+func (e *tfExporter) exportEndpointGateways() {
+	for _, vpe := range e.resources.EndpointGWList {
+		vpcID := *vpe.VPC.ID
+		body := e.config.AddResource(tfEndpointGatewaysFile, tfVirtualEndpointGateway, *vpe.Name, *vpe.ID, e.vpcRegions[vpcID])
+		body.SetAttributeValue("name", cty.StringVal(*vpe.Name))
+		body.SetAttributeRaw("vpc", e.config.Ref(vpcID, "id"))
+		if len(vpe.SecurityGroups) > 0 {
+			sgIDs := make([]string, len(vpe.SecurityGroups))
+			for i := range vpe.SecurityGroups {
+				sgIDs[i] = *vpe.SecurityGroups[i].ID
+			}
+			body.SetAttributeRaw("security_groups", e.config.Refs(sgIDs, "id"))
+		}
+		tfexport.SetStrings(body, "tags", vpe.Tags)
+		if target, ok := vpe.Target.(*vpcv1.EndpointGatewayTarget); ok {
+			targetBody := body.AppendNewBlock("target", nil).Body()
+			tfexport.SetString(targetBody, "crn", target.CRN)
+			tfexport.SetString(targetBody, "resource_type", target.ResourceType)
+		}
+		for i := range vpe.Ips {
+			subnetID := e.subnetOf(vpcID, vpe.Ips[i].Address)
+			if subnetID == "" {
+				continue
+			}
+			ipBody := body.AppendNewBlock("ips", nil).Body()
+			ipBody.SetAttributeRaw("subnet", e.config.Ref(subnetID, "id"))
+			tfexport.SetString(ipBody, "name", vpe.Ips[i].Name)
+		}
+	}
+}
+
+// subnetOf returns the ID of the subnet of a VPC containing an address, or "" if there is none
+func (e *tfExporter) subnetOf(vpcID string, address *string) string {
+	if address == nil {
+		return ""
+	}
+	addr, err := netip.ParseAddr(*address)
+	if err != nil {
+		return ""
+	}
+	for _, subnet := range e.resources.SubnetList {
+		if *subnet.VPC.ID != vpcID || subnet.Ipv4CIDRBlock == nil {
+			continue
+		}
+		if cidr, cidrErr := netip.ParsePrefix(*subnet.Ipv4CIDRBlock); cidrErr == nil && cidr.Contains(addr) {
+			return *subnet.ID
+		}
+	}
+	return ""
+}
+
+func setTrue(body *hclwrite.Body, name string, val *bool) {
+	if val != nil && *val {
+		body.SetAttributeValue(name, cty.True)
+	}
+}
+
+func firstSet(vals ...*string) *string {
+	for _, val := range vals {
+		if val != nil {
+			return val
+		}
+	}
+	return nil
+}
@@ -0,0 +1,482 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ibm
+
+import (
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/ibm/datamodel"
+	"github.com/np-guard/cloud-resource-collector/pkg/tfstate"
+)
+
+// Terraform resource types translated by FromTerraformState
+const (
+	tfVPC                 = "ibm_is_vpc"
+	tfVPCAddressPrefix    = "ibm_is_vpc_address_prefix"
+	tfSubnet              = "ibm_is_subnet"
+	tfPublicGateway       = "ibm_is_public_gateway"
+	tfFloatingIP          = "ibm_is_floating_ip"
+	tfNetworkACL          = "ibm_is_network_acl"
+	tfNetworkACLRule      = "ibm_is_network_acl_rule"
+	tfSecurityGroup       = "ibm_is_security_group"
+	tfSecurityGroupRule   = "ibm_is_security_group_rule"
+	tfSecurityGroupTarget = "ibm_is_security_group_target"
+)
+
+const (
+	crnRegionIndex = 5
+	anyIPv4        = "0.0.0.0/0"
+	minPort        = 1
+	maxPort        = 65535
+)
+
+var tfTranslatedTypes = []string{tfVPC, tfSubnet, tfPublicGateway, tfFloatingIP, tfNetworkACL, tfSecurityGroup}
+
+// tfTranslator translates IBM resources in a Terraform state into the data model.
+// The state records references between resources by ID only, so names and CRNs of referenced resources are
+// taken from the referenced resources themselves, when these are in the state
+type tfTranslator struct {
+	state *tfstate.State
+	names map[string]*string
+	crns  map[string]*string
+}
+
+// FromTerraformState creates a resources container holding the VPC resources in a Terraform state.
+// Resources that the cloud creates implicitly (e.g., the default ACL of a VPC) are included only if they are
+// managed by Terraform
+func FromTerraformState(state *tfstate.State) *ResourcesContainer {
+	t := &tfTranslator{state: state, names: map[string]*string{}, crns: map[string]*string{}}
+	for _, resourceType := range tfTranslatedTypes {
+		for _, attrs := range state.Resources(resourceType) {
+			id := attrs.String("id")
+			t.names[id] = attrs.StringPtr("name")
+			t.crns[id] = attrs.StringPtr("crn")
+		}
+	}
+	// the default ACL, security group and routing table of a VPC are usually not managed by Terraform
+	for _, attrs := range state.Resources(tfVPC) {
+		for _, defaultResource := range []string{"default_network_acl", "default_security_group", "default_routing_table"} {
+			if id := attrs.String(defaultResource); t.names[id] == nil {
+				t.names[id] = attrs.StringPtr(defaultResource + "_name")
+			}
+		}
+	}
+
+	resources := NewResourcesContainer(nil, "")
+	resources.VpcList = t.vpcs()
+	resources.SubnetList = t.subnets()
+	resources.PublicGWList = t.publicGateways()
+	resources.FloatingIPList = t.floatingIPs()
+	resources.NetworkACLList = t.networkACLs()
+	resources.SecurityGroupList = t.securityGroups()
+	return resources
+}
+
+func (t *tfTranslator) vpcs() []*datamodel.VPC {
+	res := []*datamodel.VPC{}
+	for _, attrs := range t.state.Resources(tfVPC) {
+		sdkVPC := &vpcv1.VPC{
+			ID:                   attrs.StringPtr("id"),
+			CRN:                  attrs.StringPtr("crn"),
+			Name:                 attrs.StringPtr("name"),
+			ClassicAccess:        attrs.BoolPtr("classic_access"),
+			Status:               attrs.StringPtr("status"),
+			ResourceGroup:        resourceGroupRef(attrs.String("resource_group")),
+			ResourceType:         core.StringPtr(vpcv1.VPCReferenceResourceTypeVPCConst),
+			DefaultNetworkACL:    t.networkACLRef(attrs.String("default_network_acl")),
+			DefaultSecurityGroup: t.securityGroupRef(attrs.String("default_security_group")),
+			DefaultRoutingTable:  t.routingTableRef(attrs.String("default_routing_table")),
+		}
+		vpc := datamodel.NewVPC(sdkVPC, regionFromCRN(attrs.String("crn")), t.addressPrefixes(attrs))
+		vpc.Tags = attrs.Strings("tags")
+		res = append(res, vpc)
+	}
+	return res
+}
+
+// addressPrefixes returns the default address prefixes of a VPC, followed by the prefixes added to it explicitly
+func (t *tfTranslator) addressPrefixes(vpcAttrs tfstate.Attributes) []vpcv1.AddressPrefix {
+	res := []vpcv1.AddressPrefix{}
+	defaultPrefixes := vpcAttrs.StringMap("default_address_prefixes")
+	zones := make([]string, 0, len(defaultPrefixes))
+	for zone := range defaultPrefixes {
+		zones = append(zones, zone)
+	}
+	slices.Sort(zones)
+	for _, zone := range zones {
+		res = append(res, vpcv1.AddressPrefix{CIDR: core.StringPtr(defaultPrefixes[zone]), Zone: zoneRef(zone),
+			IsDefault: core.BoolPtr(true)})
+	}
+
+	for _, attrs := range t.state.Resources(tfVPCAddressPrefix) {
+		if attrs.String("vpc") != vpcAttrs.String("id") {
+			continue
+		}
+		res = append(res, vpcv1.AddressPrefix{
+			ID:         core.StringPtr(subresourceID(attrs.String("id"))),
+			Name:       attrs.StringPtr("name"),
+			CIDR:       attrs.StringPtr("cidr"),
+			Zone:       zoneRef(attrs.String("zone")),
+			IsDefault:  attrs.BoolPtr("is_default"),
+			HasSubnets: attrs.BoolPtr("has_subnets"),
+		})
+	}
+	return res
+}
+
+func (t *tfTranslator) subnets() []*datamodel.Subnet {
+	res := []*datamodel.Subnet{}
+	for _, attrs := range t.state.Resources(tfSubnet) {
+		sdkSubnet := &vpcv1.Subnet{
+			ID:                        attrs.StringPtr("id"),
+			CRN:                       attrs.StringPtr("crn"),
+			Name:                      attrs.StringPtr("name"),
+			VPC:                       t.vpcRef(attrs.String("vpc")),
+			Zone:                      zoneRef(attrs.String("zone")),
+			Ipv4CIDRBlock:             attrs.StringPtr("ipv4_cidr_block"),
+			IPVersion:                 attrs.StringPtr("ip_version"),
+			NetworkACL:                t.networkACLRef(attrs.String("network_acl")),
+			PublicGateway:             t.publicGatewayRef(attrs.String("public_gateway")),
+			RoutingTable:              t.routingTableRef(attrs.String("routing_table")),
+			ResourceGroup:             resourceGroupRef(attrs.String("resource_group")),
+			Status:                    attrs.StringPtr("status"),
+			TotalIpv4AddressCount:     attrs.Int64Ptr("total_ipv4_address_count"),
+			AvailableIpv4AddressCount: attrs.Int64Ptr("available_ipv4_address_count"),
+			ResourceType:              core.StringPtr(vpcv1.SubnetResourceTypeSubnetConst),
+		}
+		subnet := datamodel.NewSubnet(sdkSubnet, nil)
+		subnet.Tags = attrs.Strings("tags")
+		res = append(res, subnet)
+	}
+	return res
+}
+
+func (t *tfTranslator) publicGateways() []*datamodel.PublicGateway {
+	res := []*datamodel.PublicGateway{}
+	for _, attrs := range t.state.Resources(tfPublicGateway) {
+		sdkPGW := &vpcv1.PublicGateway{
+			ID:            attrs.StringPtr("id"),
+			CRN:           attrs.StringPtr("crn"),
+			Name:          attrs.StringPtr("name"),
+			VPC:           t.vpcRef(attrs.String("vpc")),
+			Zone:          zoneRef(attrs.String("zone")),
+			ResourceGroup: resourceGroupRef(attrs.String("resource_group")),
+			Status:        attrs.StringPtr("status"),
+			ResourceType:  core.StringPtr(vpcv1.PublicGatewayResourceTypePublicGatewayConst),
+		}
+		if fip := attrs.Block("floating_ip"); fip != nil {
+			fipID := fip.String("id")
+			sdkPGW.FloatingIP = &vpcv1.PublicGatewayFloatingIP{ID: fip.StringPtr("id"), Address: fip.StringPtr("address"),
+				Name: t.names[fipID], CRN: t.crns[fipID]}
+		}
+		pgw := datamodel.NewPublicGateway(sdkPGW)
+		pgw.Tags = attrs.Strings("tags")
+		res = append(res, pgw)
+	}
+	return res
+}
+
+func (t *tfTranslator) floatingIPs() []*datamodel.FloatingIP {
+	res := []*datamodel.FloatingIP{}
+	for _, attrs := range t.state.Resources(tfFloatingIP) {
+		sdkFIP := &vpcv1.FloatingIP{
+			ID:            attrs.StringPtr("id"),
+			CRN:           attrs.StringPtr("crn"),
+			Name:          attrs.StringPtr("name"),
+			Address:       attrs.StringPtr("address"),
+			Zone:          zoneRef(attrs.String("zone")),
+			ResourceGroup: resourceGroupRef(attrs.String("resource_group")),
+			Status:        attrs.StringPtr("status"),
+		}
+		if target := attrs.String("target"); target != "" {
+			sdkFIP.Target = &vpcv1.FloatingIPTarget{ID: &target, Name: t.names[target]}
+		}
+		fip := datamodel.NewFloatingIP(sdkFIP)
+		fip.Tags = attrs.Strings("tags")
+		res = append(res, fip)
+	}
+	return res
+}
+
+func (t *tfTranslator) networkACLs() []*datamodel.NetworkACL {
+	res := []*datamodel.NetworkACL{}
+	for _, attrs := range t.state.Resources(tfNetworkACL) {
+		aclID := attrs.String("id")
+		sdkACL := &vpcv1.NetworkACL{
+			ID:            attrs.StringPtr("id"),
+			CRN:           attrs.StringPtr("crn"),
+			Name:          attrs.StringPtr("name"),
+			VPC:           t.vpcRef(attrs.String("vpc")),
+			ResourceGroup: resourceGroupRef(attrs.String("resource_group")),
+			Rules:         []vpcv1.NetworkACLRuleItemIntf{},
+			Subnets:       []vpcv1.SubnetReference{},
+		}
+		for _, ruleAttrs := range attrs.Blocks("rules") {
+			sdkACL.Rules = append(sdkACL.Rules, networkACLRule(ruleAttrs))
+		}
+		// rules managed as separate resources also appear in the rules of the ACL, once the state is refreshed
+		for _, ruleAttrs := range t.state.Resources(tfNetworkACLRule) {
+			if ruleAttrs.String("network_acl") == aclID && !slices.ContainsFunc(sdkACL.Rules, func(rule vpcv1.NetworkACLRuleItemIntf) bool {
+				return ruleID(rule) == ruleAttrs.String("rule_id")
+			}) {
+				sdkACL.Rules = append(sdkACL.Rules, networkACLRule(ruleAttrs))
+			}
+		}
+		for _, subnetAttrs := range t.state.Resources(tfSubnet) {
+			if subnetAttrs.String("network_acl") == aclID {
+				sdkACL.Subnets = append(sdkACL.Subnets, *t.subnetRef(subnetAttrs.String("id")))
+			}
+		}
+		acl := datamodel.NewNetworkACL(sdkACL)
+		acl.Tags = attrs.Strings("tags")
+		res = append(res, acl)
+	}
+	return res
+}
+
+func networkACLRule(attrs tfstate.Attributes) vpcv1.NetworkACLRuleItemIntf {
+	id := attrs.StringPtr("rule_id")
+	if id == nil {
+		id = attrs.StringPtr("id")
+	}
+	protocol, params := ruleProtocol(attrs)
+	switch protocol {
+	case vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAllProtocolAllConst:
+		return &vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAll{ID: id, Name: attrs.StringPtr("name"),
+			Action: attrs.StringPtr("action"), Direction: attrs.StringPtr("direction"), IPVersion: attrs.StringPtr("ip_version"),
+			Source: attrs.StringPtr("source"), Destination: attrs.StringPtr("destination"), Protocol: &protocol}
+	case vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolIcmpProtocolIcmpConst:
+		icmpType, icmpCode := icmpTypeCode(params)
+		return &vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolIcmp{ID: id, Name: attrs.StringPtr("name"),
+			Action: attrs.StringPtr("action"), Direction: attrs.StringPtr("direction"), IPVersion: attrs.StringPtr("ip_version"),
+			Source: attrs.StringPtr("source"), Destination: attrs.StringPtr("destination"), Protocol: &protocol,
+			Type: icmpType, Code: icmpCode}
+	default:
+		return &vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolTcpudp{ID: id, Name: attrs.StringPtr("name"),
+			Action: attrs.StringPtr("action"), Direction: attrs.StringPtr("direction"), IPVersion: attrs.StringPtr("ip_version"),
+			Source: attrs.StringPtr("source"), Destination: attrs.StringPtr("destination"), Protocol: &protocol,
+			DestinationPortMin: portOrDefault(params, "port_min", minPort), DestinationPortMax: portOrDefault(params, "port_max", maxPort),
+			SourcePortMin: portOrDefault(params, "source_port_min", minPort), SourcePortMax: portOrDefault(params, "source_port_max", maxPort)}
+	}
+}
+
+func (t *tfTranslator) securityGroups() []*datamodel.SecurityGroup {
+	res := []*datamodel.SecurityGroup{}
+	for _, attrs := range t.state.Resources(tfSecurityGroup) {
+		sgID := attrs.String("id")
+		sdkSG := &vpcv1.SecurityGroup{
+			ID:            attrs.StringPtr("id"),
+			CRN:           attrs.StringPtr("crn"),
+			Name:          attrs.StringPtr("name"),
+			VPC:           t.vpcRef(attrs.String("vpc")),
+			ResourceGroup: resourceGroupRef(attrs.String("resource_group")),
+			Rules:         []vpcv1.SecurityGroupRuleIntf{},
+			Targets:       []vpcv1.SecurityGroupTargetReferenceIntf{},
+		}
+		for _, ruleAttrs := range attrs.Blocks("rules") {
+			sdkSG.Rules = append(sdkSG.Rules, t.securityGroupRule(ruleAttrs))
+		}
+		// rules managed as separate resources also appear in the rules of the group, once the state is refreshed
+		for _, ruleAttrs := range t.state.Resources(tfSecurityGroupRule) {
+			if ruleAttrs.String("group") == sgID && !slices.ContainsFunc(sdkSG.Rules, func(rule vpcv1.SecurityGroupRuleIntf) bool {
+				return ruleID(rule) == ruleAttrs.String("rule_id")
+			}) {
+				sdkSG.Rules = append(sdkSG.Rules, t.securityGroupRule(ruleAttrs))
+			}
+		}
+		for _, targetAttrs := range t.state.Resources(tfSecurityGroupTarget) {
+			if targetAttrs.String("security_group") == sgID {
+				sdkSG.Targets = append(sdkSG.Targets, &vpcv1.SecurityGroupTargetReference{ID: targetAttrs.StringPtr("target"),
+					Name: targetAttrs.StringPtr("name"), ResourceType: targetAttrs.StringPtr("resource_type")})
+			}
+		}
+		sg := datamodel.NewSecurityGroup(sdkSG)
+		sg.Tags = attrs.Strings("tags")
+		res = append(res, sg)
+	}
+	return res
+}
+
+func (t *tfTranslator) securityGroupRule(attrs tfstate.Attributes) vpcv1.SecurityGroupRuleIntf {
+	id := attrs.StringPtr("rule_id")
+	remote := t.securityGroupRuleRemote(attrs.String("remote"))
+	local := securityGroupRuleLocal(attrs.String("local"))
+	protocol, params := ruleProtocol(attrs)
+	switch protocol {
+	case vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAllProtocolAllConst:
+		return &vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll{ID: id, Direction: attrs.StringPtr("direction"),
+			IPVersion: attrs.StringPtr("ip_version"), Protocol: &protocol, Remote: remote, Local: local}
+	case vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolIcmpProtocolIcmpConst:
+		icmpType, icmpCode := icmpTypeCode(params)
+		return &vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolIcmp{ID: id, Direction: attrs.StringPtr("direction"),
+			IPVersion: attrs.StringPtr("ip_version"), Protocol: &protocol, Remote: remote, Local: local,
+			Type: icmpType, Code: icmpCode}
+	default:
+		return &vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp{ID: id, Direction: attrs.StringPtr("direction"),
+			IPVersion: attrs.StringPtr("ip_version"), Protocol: &protocol, Remote: remote, Local: local,
+			PortMin: port(params, "port_min"), PortMax: port(params, "port_max")}
+	}
+}
+
+// securityGroupRuleRemote translates the remote of a rule, which is either an IP address, a CIDR or a security group ID
+func (t *tfTranslator) securityGroupRuleRemote(remote string) vpcv1.SecurityGroupRuleRemoteIntf {
+	switch {
+	case remote == "":
+		return &vpcv1.SecurityGroupRuleRemoteCIDR{CIDRBlock: core.StringPtr(anyIPv4)}
+	case strings.Contains(remote, "/"):
+		return &vpcv1.SecurityGroupRuleRemoteCIDR{CIDRBlock: &remote}
+	case net.ParseIP(remote) != nil:
+		return &vpcv1.SecurityGroupRuleRemoteIP{Address: &remote}
+	default:
+		return &vpcv1.SecurityGroupRuleRemoteSecurityGroupReference{ID: &remote, Name: t.names[remote], CRN: t.crns[remote]}
+	}
+}
+
+func securityGroupRuleLocal(local string) vpcv1.SecurityGroupRuleLocalIntf {
+	switch {
+	case local == "":
+		return &vpcv1.SecurityGroupRuleLocalCIDR{CIDRBlock: core.StringPtr(anyIPv4)}
+	case strings.Contains(local, "/"):
+		return &vpcv1.SecurityGroupRuleLocalCIDR{CIDRBlock: &local}
+	default:
+		return &vpcv1.SecurityGroupRuleLocalIP{Address: &local}
+	}
+}
+
+// ruleProtocol returns the protocol of an ACL or a security group rule, together with the attributes holding its
+// parameters (ports or ICMP type and code). These are either in a nested icmp/tcp/udp block (older provider versions),
+// or in the rule itself
+func ruleProtocol(attrs tfstate.Attributes) (protocol string, params tfstate.Attributes) {
+	for _, name := range []string{"icmp", "tcp", "udp"} {
+		if block := attrs.Block(name); block != nil {
+			return name, block
+		}
+	}
+	if name := attrs.String("protocol"); name != "" && name != "all" {
+		return name, attrs
+	}
+	return "all", nil
+}
+
+// icmpTypeCode returns the ICMP type and code of a rule, or nil if unset (null or missing). Zeros are kept as is,
+// as type 0 with code 0 is echo-reply rather than any ICMP traffic
+func icmpTypeCode(params tfstate.Attributes) (icmpType, icmpCode *int64) {
+	return params.Int64Ptr("type"), params.Int64Ptr("code")
+}
+
+// port returns a port of a rule, or nil if unset (recorded as 0 by some provider versions)
+func port(params tfstate.Attributes, key string) *int64 {
+	val := params.Int64Ptr(key)
+	if val == nil || *val == 0 {
+		return nil
+	}
+	return val
+}
+
+func portOrDefault(params tfstate.Attributes, key string, defaultPort int64) *int64 {
+	if val := port(params, key); val != nil {
+		return val
+	}
+	return &defaultPort
+}
+
+func ruleID(rule any) string {
+	var id *string
+	switch rule := rule.(type) {
+	case *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolAll:
+		id = rule.ID
+	case *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolIcmp:
+		id = rule.ID
+	case *vpcv1.NetworkACLRuleItemNetworkACLRuleProtocolTcpudp:
+		id = rule.ID
+	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolAll:
+		id = rule.ID
+	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolIcmp:
+		id = rule.ID
+	case *vpcv1.SecurityGroupRuleSecurityGroupRuleProtocolTcpudp:
+		id = rule.ID
+	}
+	if id == nil {
+		return ""
+	}
+	return *id
+}
+
+func (t *tfTranslator) vpcRef(id string) *vpcv1.VPCReference {
+	if id == "" {
+		return nil
+	}
+	return &vpcv1.VPCReference{ID: &id, Name: t.names[id], CRN: t.crns[id], ResourceType: core.StringPtr(vpcType)}
+}
+
+func (t *tfTranslator) subnetRef(id string) *vpcv1.SubnetReference {
+	return &vpcv1.SubnetReference{ID: &id, Name: t.names[id], CRN: t.crns[id],
+		ResourceType: core.StringPtr(vpcv1.SubnetReferenceResourceTypeSubnetConst)}
+}
+
+func (t *tfTranslator) networkACLRef(id string) *vpcv1.NetworkACLReference {
+	if id == "" {
+		return nil
+	}
+	return &vpcv1.NetworkACLReference{ID: &id, Name: t.names[id], CRN: t.crns[id]}
+}
+
+func (t *tfTranslator) publicGatewayRef(id string) *vpcv1.PublicGatewayReference {
+	if id == "" {
+		return nil
+	}
+	return &vpcv1.PublicGatewayReference{ID: &id, Name: t.names[id], CRN: t.crns[id],
+		ResourceType: core.StringPtr(vpcv1.PublicGatewayReferenceResourceTypePublicGatewayConst)}
+}
+
+func (t *tfTranslator) securityGroupRef(id string) *vpcv1.SecurityGroupReference {
+	if id == "" {
+		return nil
+	}
+	return &vpcv1.SecurityGroupReference{ID: &id, Name: t.names[id], CRN: t.crns[id]}
+}
+
+func (t *tfTranslator) routingTableRef(id string) *vpcv1.RoutingTableReference {
+	if id == "" {
+		return nil
+	}
+	return &vpcv1.RoutingTableReference{ID: &id, Name: t.names[id], CRN: t.crns[id],
+		ResourceType: core.StringPtr(vpcv1.RoutingTableReferenceResourceTypeRoutingTableConst)}
+}
+
+func resourceGroupRef(id string) *vpcv1.ResourceGroupReference {
+	if id == "" {
+		return nil
+	}
+	return &vpcv1.ResourceGroupReference{ID: &id}
+}
+
+func zoneRef(zone string) *vpcv1.ZoneReference {
+	if zone == "" {
+		return nil
+	}
+	return getZoneRef(zone)
+}
+
+// regionFromCRN returns the region in a CRN of the form crn:v1:bluemix:public:is:<region>:a/<account>::<type>:<id>
+func regionFromCRN(crn string) string {
+	parts := strings.Split(crn, ":")
+	if len(parts) <= crnRegionIndex {
+		return ""
+	}
+	return parts[crnRegionIndex]
+}
+
+// subresourceID returns the ID of a resource whose Terraform ID is composed of its parent's ID and its own ID
+func subresourceID(tfID string) string {
+	return tfID[strings.LastIndex(tfID, "/")+1:]
+}
@@ -8,7 +8,10 @@ package ibm
 
 import (
 	"fmt"
+	"log"
 	"reflect"
+	"slices"
+	"time"
 
 	tgw "github.com/IBM/networking-go-sdk/transitgatewayapisv1"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
@@ -18,6 +21,13 @@ import (
 
 const pageSize = 50
 
+const (
+	routeReportComplete     = "complete"
+	routeReportMaxAge       = time.Hour
+	routeReportTimeout      = 2 * time.Minute
+	routeReportPollInterval = 5 * time.Second
+)
+
 type HasGetNextStart[T any] interface {
 	GetNextStart() (*string, error)
 }
@@ -176,8 +186,35 @@ func getSecurityGroups(vpcService *vpcv1.VpcV1, resourceGroupID string) ([]*data
 	securityGroupGetArray := func(collection *vpcv1.SecurityGroupCollection) []vpcv1.SecurityGroup {
 		return collection.SecurityGroups
 	}
+	securityGroups, err := getResources(securityGroupAPIFunc, securityGroupGetArray, datamodel.NewSecurityGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replace the targets in the listed security groups with all their targets
+	for _, securityGroup := range securityGroups {
+		securityGroup.Targets, err = getSecurityGroupTargets(vpcService, *securityGroup.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return securityGroups, nil
+}
 
-	return getResources(securityGroupAPIFunc, securityGroupGetArray, datamodel.NewSecurityGroup)
+// Get all targets (e.g., network interfaces, VNIs, load balancers, endpoint gateways and VPN servers) of a security group
+func getSecurityGroupTargets(vpcService *vpcv1.VpcV1, sgID string) ([]vpcv1.SecurityGroupTargetReferenceIntf, error) {
+	targetAPIFunc := func(pageSize int64, next *string) (*vpcv1.SecurityGroupTargetCollection, any, error) {
+		return vpcService.ListSecurityGroupTargets(&vpcv1.ListSecurityGroupTargetsOptions{SecurityGroupID: &sgID,
+			Limit: &pageSize, Start: next})
+	}
+	targetGetArray := func(collection *vpcv1.SecurityGroupTargetCollection) []vpcv1.SecurityGroupTargetReferenceIntf {
+		return collection.Targets
+	}
+	targets, err := iteratePagedAPI(targetAPIFunc, targetGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getSecurityGroupTargets] error getting targets for %s: %w", sgID, err)
+	}
+	return targets, nil
 }
 
 // Get all Endpoint Gateways (VPEs)
@@ -209,11 +246,22 @@ func getInstances(vpcService *vpcv1.VpcV1, resourceGroupID string) ([]*datamodel
 		id := *instances[i].ID
 		name := *instances[i].Name
 
-		networkInterfaces, err := getNetworkInterface(vpcService, id, name)
-		if err != nil {
-			return nil, err
+		// An instance has either network interfaces (legacy) or network attachments (binding virtual network interfaces)
+		networkInterfaces := []vpcv1.NetworkInterface{}
+		if instances[i].PrimaryNetworkInterface != nil {
+			networkInterfaces, err = getNetworkInterface(vpcService, id, name)
+			if err != nil {
+				return nil, err
+			}
 		}
-		res[i] = datamodel.NewInstance(&instances[i], networkInterfaces)
+		networkAttachments := []vpcv1.InstanceNetworkAttachment{}
+		if instances[i].PrimaryNetworkAttachment != nil {
+			networkAttachments, err = getNetworkAttachments(vpcService, id, name)
+			if err != nil {
+				return nil, err
+			}
+		}
+		res[i] = datamodel.NewInstance(&instances[i], networkInterfaces, networkAttachments)
 	}
 
 	return res, nil
@@ -230,6 +278,102 @@ func getNetworkInterface(vpcService *vpcv1.VpcV1, id, name string) ([]vpcv1.Netw
 	return networkInterfaces.NetworkInterfaces, nil
 }
 
+// Second API call to get detailed network attachments information
+func getNetworkAttachments(vpcService *vpcv1.VpcV1, id, name string) ([]vpcv1.InstanceNetworkAttachment, error) {
+	networkAttachments, _, err := vpcService.ListInstanceNetworkAttachments(&vpcv1.ListInstanceNetworkAttachmentsOptions{InstanceID: &id})
+	if err != nil {
+		return nil, fmt.Errorf("[getInstances] error getting network attachments for %s: %w", name, err)
+	}
+	return networkAttachments.NetworkAttachments, nil
+}
+
+// getAttachedVirtualNIs returns the virtual network interfaces attached to instances which are not in the given list
+// (e.g., because they belong to another resource group), so that every network attachment refers to a collected VNI
+func getAttachedVirtualNIs(vpcService *vpcv1.VpcV1, instances []*datamodel.Instance,
+	vnis []*datamodel.VirtualNI) ([]*datamodel.VirtualNI, error) {
+	collected := map[string]bool{}
+	for _, vni := range vnis {
+		collected[*vni.ID] = true
+	}
+	res := []*datamodel.VirtualNI{}
+	for _, instance := range instances {
+		for i := range instance.NetworkAttachments {
+			vniRef := instance.NetworkAttachments[i].VirtualNetworkInterface
+			if vniRef == nil || collected[*vniRef.ID] {
+				continue
+			}
+			vni, _, err := vpcService.GetVirtualNetworkInterface(&vpcv1.GetVirtualNetworkInterfaceOptions{ID: vniRef.ID})
+			if err != nil {
+				return nil, fmt.Errorf("[getAttachedVirtualNIs] error getting virtual network interface %s: %w", *vniRef.ID, err)
+			}
+			collected[*vniRef.ID] = true
+			res = append(res, datamodel.NewVirtualNI(vni))
+		}
+	}
+	return res, nil
+}
+
+// Get all bare metal servers, with their network interfaces and network attachments
+func getBareMetalServers(vpcService *vpcv1.VpcV1, resourceGroupID string) ([]*datamodel.BareMetalServer, error) {
+	bareMetalServerAPIFunc := func(pageSize int64, next *string) (*vpcv1.BareMetalServerCollection, any, error) {
+		return vpcService.ListBareMetalServers(&vpcv1.ListBareMetalServersOptions{Limit: &pageSize, Start: next,
+			ResourceGroupID: &resourceGroupID})
+	}
+	bareMetalServerGetArray := func(collection *vpcv1.BareMetalServerCollection) []vpcv1.BareMetalServer {
+		return collection.BareMetalServers
+	}
+	bareMetalServers, err := iteratePagedAPI(bareMetalServerAPIFunc, bareMetalServerGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getBareMetalServers] error getting bare metal servers: %w", err)
+	}
+	res := make([]*datamodel.BareMetalServer, len(bareMetalServers))
+	for i := range bareMetalServers {
+		id := *bareMetalServers[i].ID
+		networkInterfaces, nicErr := getBareMetalServerNetworkInterfaces(vpcService, id)
+		if nicErr != nil {
+			return nil, nicErr
+		}
+		networkAttachments, attErr := getBareMetalServerNetworkAttachments(vpcService, id)
+		if attErr != nil {
+			return nil, attErr
+		}
+		res[i] = datamodel.NewBareMetalServer(&bareMetalServers[i], networkInterfaces, networkAttachments)
+	}
+
+	return res, nil
+}
+
+func getBareMetalServerNetworkInterfaces(vpcService *vpcv1.VpcV1, id string) ([]vpcv1.BareMetalServerNetworkInterfaceIntf, error) {
+	networkInterfaceAPIFunc := func(pageSize int64, next *string) (*vpcv1.BareMetalServerNetworkInterfaceCollection, any, error) {
+		return vpcService.ListBareMetalServerNetworkInterfaces(&vpcv1.ListBareMetalServerNetworkInterfacesOptions{
+			BareMetalServerID: &id, Limit: &pageSize, Start: next})
+	}
+	networkInterfaceGetArray := func(collection *vpcv1.BareMetalServerNetworkInterfaceCollection) []vpcv1.BareMetalServerNetworkInterfaceIntf {
+		return collection.NetworkInterfaces
+	}
+	networkInterfaces, err := iteratePagedAPI(networkInterfaceAPIFunc, networkInterfaceGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getBareMetalServerNetworkInterfaces] error getting network interfaces for %s: %w", id, err)
+	}
+	return networkInterfaces, nil
+}
+
+func getBareMetalServerNetworkAttachments(vpcService *vpcv1.VpcV1, id string) ([]vpcv1.BareMetalServerNetworkAttachmentIntf, error) {
+	networkAttachmentAPIFunc := func(pageSize int64, next *string) (*vpcv1.BareMetalServerNetworkAttachmentCollection, any, error) {
+		return vpcService.ListBareMetalServerNetworkAttachments(&vpcv1.ListBareMetalServerNetworkAttachmentsOptions{
+			BareMetalServerID: &id, Limit: &pageSize, Start: next})
+	}
+	networkAttachmentGetArray := func(
+		collection *vpcv1.BareMetalServerNetworkAttachmentCollection) []vpcv1.BareMetalServerNetworkAttachmentIntf {
+		return collection.NetworkAttachments
+	}
+	networkAttachments, err := iteratePagedAPI(networkAttachmentAPIFunc, networkAttachmentGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getBareMetalServerNetworkAttachments] error getting network attachments for %s: %w", id, err)
+	}
+	return networkAttachments, nil
+}
+
 func getVirtualNIs(vpcService *vpcv1.VpcV1, resourceGroupID string) ([]*datamodel.VirtualNI, error) {
 	vniAPIFunc := func(pageSize int64, next *string) (*vpcv1.VirtualNetworkInterfaceCollection, any, error) {
 		opts := vpcv1.ListVirtualNetworkInterfacesOptions{Limit: &pageSize, Start: next, ResourceGroupID: &resourceGroupID}
@@ -319,57 +463,155 @@ func getLoadBalancers(vpcService *vpcv1.VpcV1, resourceGroupID string) ([]*datam
 		if resourceGroupID != "" && *(loadBalancers[i].ResourceGroup.ID) != resourceGroupID {
 			continue
 		}
+		lb, lbErr := getLoadBalancerDetails(vpcService, &loadBalancers[i])
+		if lbErr != nil {
+			return nil, lbErr
+		}
+		res = append(res, lb)
+	}
 
-		// get all the listeners
-		lbID := *loadBalancers[i].ID
-		listenerOptions := &vpcv1.ListLoadBalancerListenersOptions{}
-		listenerOptions.SetLoadBalancerID(lbID)
-		listenersCollection, _, err := vpcService.ListLoadBalancerListeners(listenerOptions)
-		if err != nil {
-			return nil, fmt.Errorf("[getLoadBalancers] error getting listeners for %s: %w", lbID, err)
+	return res, nil
+}
+
+// getLoadBalancerDetails gets the listeners (with their policies) and pools (with their members) of a load balancer
+func getLoadBalancerDetails(vpcService *vpcv1.VpcV1, loadBalancer *vpcv1.LoadBalancer) (*datamodel.LoadBalancer, error) {
+	// get all the listeners
+	lbID := *loadBalancer.ID
+	listenerOptions := &vpcv1.ListLoadBalancerListenersOptions{}
+	listenerOptions.SetLoadBalancerID(lbID)
+	listenersCollection, _, err := vpcService.ListLoadBalancerListeners(listenerOptions)
+	if err != nil {
+		return nil, fmt.Errorf("[getLoadBalancerDetails] error getting listeners for %s: %w", lbID, err)
+	}
+
+	listeners := make([]datamodel.LoadBalancerListener, len(listenersCollection.Listeners))
+	for j := range listenersCollection.Listeners {
+		listenerID := *listenersCollection.Listeners[j].ID
+		policiesOptions := &vpcv1.ListLoadBalancerListenerPoliciesOptions{}
+		policiesOptions.SetLoadBalancerID(lbID)
+		policiesOptions.SetListenerID(listenerID)
+		policiesCollection, _, polErr := vpcService.ListLoadBalancerListenerPolicies(policiesOptions)
+		if polErr != nil {
+			return nil, fmt.Errorf("[getLoadBalancerDetails] error getting policies for %s: %w", listenerID, polErr)
 		}
 
-		listeners := make([]datamodel.LoadBalancerListener, len(listenersCollection.Listeners))
-		for j := range listenersCollection.Listeners {
-			listenerID := *listenersCollection.Listeners[j].ID
-			policiesOptions := &vpcv1.ListLoadBalancerListenerPoliciesOptions{}
-			policiesOptions.SetLoadBalancerID(lbID)
-			policiesOptions.SetListenerID(listenerID)
-			policiesCollection, _, polErr := vpcService.ListLoadBalancerListenerPolicies(policiesOptions)
+		policies := make([]datamodel.LoadBalancerListenerPolicy, len(policiesCollection.Policies))
+		for k := range policiesCollection.Policies {
+			policies[k], polErr = getPolicyRules(vpcService, lbID, listenerID, &policiesCollection.Policies[k])
 			if polErr != nil {
-				return nil, fmt.Errorf("[getLoadBalancers] error getting policies for %s: %w", listenerID, err)
-			}
-
-			policies := make([]datamodel.LoadBalancerListenerPolicy, len(policiesCollection.Policies))
-			for k := range policiesCollection.Policies {
-				policies[k], polErr = getPolicyRules(vpcService, lbID, listenerID, &policiesCollection.Policies[k])
-				if polErr != nil {
-					return nil, polErr
-				}
+				return nil, polErr
 			}
-			listeners[j] = datamodel.NewLoadBalancerListener(&listenersCollection.Listeners[j], policies)
 		}
+		listeners[j] = datamodel.NewLoadBalancerListener(&listenersCollection.Listeners[j], policies)
+	}
 
-		// get all the pools
-		poolOptions := &vpcv1.ListLoadBalancerPoolsOptions{}
-		poolOptions.SetLoadBalancerID(lbID)
-		poolsCollection, _, err := vpcService.ListLoadBalancerPools(poolOptions)
+	// get all the pools
+	poolOptions := &vpcv1.ListLoadBalancerPoolsOptions{}
+	poolOptions.SetLoadBalancerID(lbID)
+	poolsCollection, _, err := vpcService.ListLoadBalancerPools(poolOptions)
+	if err != nil {
+		return nil, fmt.Errorf("[getLoadBalancerDetails] error getting pools for %s: %w", lbID, err)
+	}
+	pools := make([]datamodel.LoadBalancerPool, len(poolsCollection.Pools))
+	for j := range pools {
+		pools[j], err = getPoolMembers(vpcService, lbID, &poolsCollection.Pools[j])
 		if err != nil {
-			return nil, fmt.Errorf("[getLoadBalancers] error getting pools for %s: %w", lbID, err)
+			return nil, err
 		}
-		pools := make([]datamodel.LoadBalancerPool, len(poolsCollection.Pools))
-		for j := range pools {
-			pools[j], err = getPoolMembers(vpcService, lbID, &poolsCollection.Pools[j])
-			if err != nil {
-				return nil, err
-			}
+	}
+	return datamodel.NewLoadBalancer(loadBalancer, listeners, pools), nil
+}
+
+// Get all Private Path service gateways, with their account policies and endpoint gateway bindings
+func getPrivatePathServiceGateways(vpcService *vpcv1.VpcV1, resourceGroupID string) ([]*datamodel.PrivatePathServiceGateway, error) {
+	ppsgAPIFunc := func(pageSize int64, next *string) (*vpcv1.PrivatePathServiceGatewayCollection, any, error) {
+		return vpcService.ListPrivatePathServiceGateways(&vpcv1.ListPrivatePathServiceGatewaysOptions{Limit: &pageSize, Start: next,
+			ResourceGroupID: &resourceGroupID})
+	}
+	ppsgGetArray := func(collection *vpcv1.PrivatePathServiceGatewayCollection) []vpcv1.PrivatePathServiceGateway {
+		return collection.PrivatePathServiceGateways
+	}
+	ppsgs, err := iteratePagedAPI(ppsgAPIFunc, ppsgGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getPrivatePathServiceGateways] error getting Private Path service gateways: %w", err)
+	}
+	res := make([]*datamodel.PrivatePathServiceGateway, len(ppsgs))
+	for i := range ppsgs {
+		policies, policiesErr := getPPSGAccountPolicies(vpcService, *ppsgs[i].ID)
+		if policiesErr != nil {
+			return nil, policiesErr
 		}
-		res = append(res, datamodel.NewLoadBalancer(&loadBalancers[i], listeners, pools))
+		bindings, bindingsErr := getPPSGEndpointGatewayBindings(vpcService, *ppsgs[i].ID)
+		if bindingsErr != nil {
+			return nil, bindingsErr
+		}
+		res[i] = datamodel.NewPrivatePathServiceGateway(&ppsgs[i], policies, bindings)
 	}
 
 	return res, nil
 }
 
+func getPPSGAccountPolicies(vpcService *vpcv1.VpcV1, ppsgID string) ([]vpcv1.PrivatePathServiceGatewayAccountPolicy, error) {
+	policyAPIFunc := func(pageSize int64, next *string) (*vpcv1.PrivatePathServiceGatewayAccountPolicyCollection, any, error) {
+		return vpcService.ListPrivatePathServiceGatewayAccountPolicies(&vpcv1.ListPrivatePathServiceGatewayAccountPoliciesOptions{
+			PrivatePathServiceGatewayID: &ppsgID, Limit: &pageSize, Start: next})
+	}
+	policyGetArray := func(
+		collection *vpcv1.PrivatePathServiceGatewayAccountPolicyCollection) []vpcv1.PrivatePathServiceGatewayAccountPolicy {
+		return collection.AccountPolicies
+	}
+	policies, err := iteratePagedAPI(policyAPIFunc, policyGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getPPSGAccountPolicies] error getting account policies for %s: %w", ppsgID, err)
+	}
+	return policies, nil
+}
+
+func getPPSGEndpointGatewayBindings(vpcService *vpcv1.VpcV1,
+	ppsgID string) ([]vpcv1.PrivatePathServiceGatewayEndpointGatewayBinding, error) {
+	bindingAPIFunc := func(pageSize int64, next *string) (*vpcv1.PrivatePathServiceGatewayEndpointGatewayBindingCollection, any, error) {
+		return vpcService.ListPrivatePathServiceGatewayEndpointGatewayBindings(
+			&vpcv1.ListPrivatePathServiceGatewayEndpointGatewayBindingsOptions{PrivatePathServiceGatewayID: &ppsgID,
+				Limit: &pageSize, Start: next})
+	}
+	bindingGetArray := func(
+		collection *vpcv1.PrivatePathServiceGatewayEndpointGatewayBindingCollection) []vpcv1.PrivatePathServiceGatewayEndpointGatewayBinding {
+		return collection.EndpointGatewayBindings
+	}
+	bindings, err := iteratePagedAPI(bindingAPIFunc, bindingGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getPPSGEndpointGatewayBindings] error getting endpoint gateway bindings for %s: %w", ppsgID, err)
+	}
+	return bindings, nil
+}
+
+// getPrivatePathLoadBalancers returns the load balancers backing Private Path service gateways which are not in the given
+// list (e.g., because they belong to another resource group), so that every gateway refers to a collected load balancer
+func getPrivatePathLoadBalancers(vpcService *vpcv1.VpcV1, ppsgs []*datamodel.PrivatePathServiceGateway,
+	lbs []*datamodel.LoadBalancer) ([]*datamodel.LoadBalancer, error) {
+	collected := map[string]bool{}
+	for _, lb := range lbs {
+		collected[*lb.ID] = true
+	}
+	res := []*datamodel.LoadBalancer{}
+	for _, ppsg := range ppsgs {
+		if ppsg.LoadBalancer == nil || collected[*ppsg.LoadBalancer.ID] {
+			continue
+		}
+		loadBalancer, _, err := vpcService.GetLoadBalancer(&vpcv1.GetLoadBalancerOptions{ID: ppsg.LoadBalancer.ID})
+		if err != nil {
+			return nil, fmt.Errorf("[getPrivatePathLoadBalancers] error getting load balancer %s: %w", *ppsg.LoadBalancer.ID, err)
+		}
+		lb, err := getLoadBalancerDetails(vpcService, loadBalancer)
+		if err != nil {
+			return nil, err
+		}
+		collected[*ppsg.LoadBalancer.ID] = true
+		res = append(res, lb)
+	}
+	return res, nil
+}
+
 func getPoolMembers(vpcService *vpcv1.VpcV1, lbID string, vpcPool *vpcv1.LoadBalancerPool) (datamodel.LoadBalancerPool, error) {
 	options := &vpcv1.ListLoadBalancerPoolMembersOptions{}
 	options.SetLoadBalancerID(lbID)
@@ -397,6 +639,156 @@ func getPolicyRules(vpcService *vpcv1.VpcV1, lbID, listenerID string,
 	return policy, nil
 }
 
+func getFlowLogCollectors(vpcService *vpcv1.VpcV1, resourceGroupID string) ([]*datamodel.FlowLogCollector, error) {
+	flowLogCollectorAPIFunc := func(pageSize int64, next *string) (*vpcv1.FlowLogCollectorCollection, any, error) {
+		return vpcService.ListFlowLogCollectors(&vpcv1.ListFlowLogCollectorsOptions{Limit: &pageSize, Start: next,
+			ResourceGroupID: &resourceGroupID})
+	}
+	flowLogCollectorGetArray := func(collection *vpcv1.FlowLogCollectorCollection) []vpcv1.FlowLogCollector {
+		return collection.FlowLogCollectors
+	}
+
+	return getResources(flowLogCollectorAPIFunc, flowLogCollectorGetArray, datamodel.NewFlowLogCollector)
+}
+
+// Get all VPN gateways, with their connections
+func getVPNGateways(vpcService *vpcv1.VpcV1, resourceGroupID string) ([]*datamodel.VPNGateway, error) {
+	vpnGatewayAPIFunc := func(pageSize int64, next *string) (*vpcv1.VPNGatewayCollection, any, error) {
+		return vpcService.ListVPNGateways(&vpcv1.ListVPNGatewaysOptions{Limit: &pageSize, Start: next, ResourceGroupID: &resourceGroupID})
+	}
+	vpnGatewayGetArray := func(collection *vpcv1.VPNGatewayCollection) []vpcv1.VPNGatewayIntf {
+		return collection.VPNGateways
+	}
+	vpnGateways, err := iteratePagedAPI(vpnGatewayAPIFunc, vpnGatewayGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getVPNGateways] error getting VPN gateways: %w", err)
+	}
+	res := make([]*datamodel.VPNGateway, len(vpnGateways))
+	for i := range vpnGateways {
+		res[i] = datamodel.NewVPNGateway(vpnGateways[i], nil)
+		res[i].Connections, err = getVPNGatewayConnections(vpcService, *res[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// Get all connections of a VPN gateway, with the local and peer CIDRs of policy-mode connections
+func getVPNGatewayConnections(vpcService *vpcv1.VpcV1, vpnGatewayID string) ([]datamodel.VPNGatewayConnection, error) {
+	connectionAPIFunc := func(pageSize int64, next *string) (*vpcv1.VPNGatewayConnectionCollection, any, error) {
+		return vpcService.ListVPNGatewayConnections(&vpcv1.ListVPNGatewayConnectionsOptions{VPNGatewayID: &vpnGatewayID,
+			Limit: &pageSize, Start: next})
+	}
+	connectionGetArray := func(collection *vpcv1.VPNGatewayConnectionCollection) []vpcv1.VPNGatewayConnectionIntf {
+		return collection.Connections
+	}
+	connections, err := iteratePagedAPI(connectionAPIFunc, connectionGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getVPNGatewayConnections] error getting connections for %s: %w", vpnGatewayID, err)
+	}
+	res := make([]datamodel.VPNGatewayConnection, len(connections))
+	for i := range connections {
+		res[i], err = datamodel.NewVPNGatewayConnection(connections[i])
+		if err != nil {
+			return nil, fmt.Errorf("[getVPNGatewayConnections] error converting connection of %s: %w", vpnGatewayID, err)
+		}
+	}
+
+	return res, nil
+}
+
+// Get all client-to-site VPN servers, with their routes and clients
+func getVPNServers(vpcService *vpcv1.VpcV1, resourceGroupID string) ([]*datamodel.VPNServer, error) {
+	vpnServerAPIFunc := func(pageSize int64, next *string) (*vpcv1.VPNServerCollection, any, error) {
+		return vpcService.ListVPNServers(&vpcv1.ListVPNServersOptions{Limit: &pageSize, Start: next, ResourceGroupID: &resourceGroupID})
+	}
+	vpnServerGetArray := func(collection *vpcv1.VPNServerCollection) []vpcv1.VPNServer {
+		return collection.VPNServers
+	}
+	vpnServers, err := iteratePagedAPI(vpnServerAPIFunc, vpnServerGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getVPNServers] error getting VPN servers: %w", err)
+	}
+	res := make([]*datamodel.VPNServer, len(vpnServers))
+	for i := range vpnServers {
+		routes, routesErr := getVPNServerRoutes(vpcService, *vpnServers[i].ID)
+		if routesErr != nil {
+			return nil, routesErr
+		}
+		clients, clientsErr := getVPNServerClients(vpcService, *vpnServers[i].ID)
+		if clientsErr != nil {
+			return nil, clientsErr
+		}
+		res[i] = datamodel.NewVPNServer(&vpnServers[i], routes, clients)
+	}
+
+	return res, nil
+}
+
+func getVPNServerRoutes(vpcService *vpcv1.VpcV1, vpnServerID string) ([]vpcv1.VPNServerRoute, error) {
+	routeAPIFunc := func(pageSize int64, next *string) (*vpcv1.VPNServerRouteCollection, any, error) {
+		return vpcService.ListVPNServerRoutes(&vpcv1.ListVPNServerRoutesOptions{VPNServerID: &vpnServerID, Limit: &pageSize, Start: next})
+	}
+	routeGetArray := func(collection *vpcv1.VPNServerRouteCollection) []vpcv1.VPNServerRoute {
+		return collection.Routes
+	}
+	routes, err := iteratePagedAPI(routeAPIFunc, routeGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getVPNServerRoutes] error getting routes for %s: %w", vpnServerID, err)
+	}
+	return routes, nil
+}
+
+func getVPNServerClients(vpcService *vpcv1.VpcV1, vpnServerID string) ([]vpcv1.VPNServerClient, error) {
+	clientAPIFunc := func(pageSize int64, next *string) (*vpcv1.VPNServerClientCollection, any, error) {
+		return vpcService.ListVPNServerClients(&vpcv1.ListVPNServerClientsOptions{VPNServerID: &vpnServerID, Limit: &pageSize, Start: next})
+	}
+	clientGetArray := func(collection *vpcv1.VPNServerClientCollection) []vpcv1.VPNServerClient {
+		return collection.Clients
+	}
+	clients, err := iteratePagedAPI(clientAPIFunc, clientGetArray)
+	if err != nil {
+		return nil, fmt.Errorf("[getVPNServerClients] error getting clients for %s: %w", vpnServerID, err)
+	}
+	return clients, nil
+}
+
+// Get all IKE policies (the API does not filter by resource group)
+func getIKEPolicies(vpcService *vpcv1.VpcV1, resourceGroupID string) ([]*datamodel.IKEPolicy, error) {
+	ikePolicyAPIFunc := func(pageSize int64, next *string) (*vpcv1.IkePolicyCollection, any, error) {
+		return vpcService.ListIkePolicies(&vpcv1.ListIkePoliciesOptions{Limit: &pageSize, Start: next})
+	}
+	ikePolicyGetArray := func(collection *vpcv1.IkePolicyCollection) []vpcv1.IkePolicy {
+		return collection.IkePolicies
+	}
+	ikePolicies, err := getResources(ikePolicyAPIFunc, ikePolicyGetArray, datamodel.NewIKEPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(ikePolicies, func(policy *datamodel.IKEPolicy) bool {
+		return resourceGroupID != "" && *policy.ResourceGroup.ID != resourceGroupID
+	}), nil
+}
+
+// Get all IPsec policies (the API does not filter by resource group)
+func getIPsecPolicies(vpcService *vpcv1.VpcV1, resourceGroupID string) ([]*datamodel.IPsecPolicy, error) {
+	ipsecPolicyAPIFunc := func(pageSize int64, next *string) (*vpcv1.IPsecPolicyCollection, any, error) {
+		return vpcService.ListIpsecPolicies(&vpcv1.ListIpsecPoliciesOptions{Limit: &pageSize, Start: next})
+	}
+	ipsecPolicyGetArray := func(collection *vpcv1.IPsecPolicyCollection) []vpcv1.IPsecPolicy {
+		return collection.IpsecPolicies
+	}
+	ipsecPolicies, err := getResources(ipsecPolicyAPIFunc, ipsecPolicyGetArray, datamodel.NewIPsecPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(ipsecPolicies, func(policy *datamodel.IPsecPolicy) bool {
+		return resourceGroupID != "" && *policy.ResourceGroup.ID != resourceGroupID
+	}), nil
+}
+
 func getTransitConnections(tgwService *tgw.TransitGatewayApisV1,
 	tgwList []*datamodel.TransitGateway) ([]*datamodel.TransitConnection, error) {
 	APIFunc := func(pageSize int64, next *string) (*tgw.TransitConnectionCollection, any, error) {
@@ -414,7 +806,12 @@ func getTransitConnections(tgwService *tgw.TransitGatewayApisV1,
 	for i := range transitCons {
 		for j := range tgwList {
 			if *(transitCons[i].TransitGateway.ID) == *(tgwList[j].ID) {
-				res = append(res, datamodel.NewTransitConnection(&transitCons[i]))
+				prefixFilters, _, filterErr := tgwService.ListTransitGatewayConnectionPrefixFilters(
+					&tgw.ListTransitGatewayConnectionPrefixFiltersOptions{TransitGatewayID: tgwList[j].ID, ID: transitCons[i].ID})
+				if filterErr != nil {
+					return nil, fmt.Errorf("[getTransitConnections] error getting prefix filters for %s: %w", *transitCons[i].ID, filterErr)
+				}
+				res = append(res, datamodel.NewTransitConnection(&transitCons[i], prefixFilters.PrefixFilters))
 			}
 		}
 	}
@@ -433,11 +830,95 @@ func getTransitGateways(tgwService *tgw.TransitGatewayApisV1, resourceGroupID st
 	if err != nil {
 		return nil, fmt.Errorf("[getTransitGateways] error getting transit gateways: %w", err)
 	}
-	var res []*datamodel.TransitGateway
+	transitGws = slices.DeleteFunc(transitGws, func(transitGw tgw.TransitGateway) bool {
+		return resourceGroupID != "" && *transitGw.ResourceGroup.ID != resourceGroupID
+	})
+	routeReports := getRouteReports(tgwService, transitGws)
+	res := make([]*datamodel.TransitGateway, len(transitGws))
 	for i := range transitGws {
-		if resourceGroupID == "" || *(transitGws[i].ResourceGroup.ID) == resourceGroupID {
-			res = append(res, datamodel.NewTransitGateway(&transitGws[i]))
-		}
+		res[i] = datamodel.NewTransitGateway(&transitGws[i], routeReports[i])
 	}
 	return res, nil
 }
+
+// getRouteReports returns a route report for each of the given transit gateways. A complete report generated in the last
+// routeReportMaxAge is reused; otherwise a new report is generated. The new reports of all transit gateways are polled
+// together, so collection waits at most routeReportTimeout for all of them. A report is nil if the transit gateway is
+// not available, if the report could not be read or generated (e.g., with a read-only API key), or if it did not
+// complete in time
+func getRouteReports(tgwService *tgw.TransitGatewayApisV1, transitGws []tgw.TransitGateway) []*tgw.RouteReport {
+	reports := make([]*tgw.RouteReport, len(transitGws))
+	for i := range transitGws {
+		if *transitGws[i].Status != tgw.TransitGateway_Status_Available {
+			log.Printf("Transit gateway %s is %s, its route report will not be collected\n", *transitGws[i].ID, *transitGws[i].Status)
+			continue
+		}
+		report, err := startRouteReport(tgwService, *transitGws[i].ID)
+		if err != nil {
+			log.Printf("Route report for transit gateway %s will not be collected: %v\n", *transitGws[i].ID, err)
+			continue
+		}
+		reports[i] = report
+	}
+
+	deadline := time.Now().Add(routeReportTimeout)
+	for slices.ContainsFunc(reports, isPendingRouteReport) && time.Now().Before(deadline) {
+		time.Sleep(routeReportPollInterval)
+		for i := range reports {
+			if !isPendingRouteReport(reports[i]) {
+				continue
+			}
+			tgwID := *transitGws[i].ID
+			report, _, err := tgwService.GetTransitGatewayRouteReport(&tgw.GetTransitGatewayRouteReportOptions{TransitGatewayID: &tgwID,
+				ID: reports[i].ID})
+			if err != nil {
+				log.Printf("Route report for transit gateway %s will not be collected: %v\n", tgwID, err)
+			}
+			reports[i] = report
+		}
+	}
+
+	for i := range reports {
+		if isPendingRouteReport(reports[i]) {
+			log.Printf("Route report for transit gateway %s is not complete, it will not be collected\n", *transitGws[i].ID)
+			reports[i] = nil
+		}
+	}
+	return reports
+}
+
+func isPendingRouteReport(report *tgw.RouteReport) bool {
+	return report != nil && *report.Status != routeReportComplete
+}
+
+// startRouteReport returns the latest complete route report of a transit gateway if it was generated in the last
+// routeReportMaxAge, or otherwise requests a new route report, which might still be pending
+func startRouteReport(tgwService *tgw.TransitGatewayApisV1, tgwID string) (*tgw.RouteReport, error) {
+	reports, _, err := tgwService.ListTransitGatewayRouteReports(&tgw.ListTransitGatewayRouteReportsOptions{TransitGatewayID: &tgwID})
+	if err != nil {
+		return nil, fmt.Errorf("[startRouteReport] error getting route reports for %s: %w", tgwID, err)
+	}
+	var latest *tgw.RouteReport
+	for i := range reports.RouteReports {
+		report := &reports.RouteReports[i]
+		if *report.Status == routeReportComplete && (latest == nil || reportTime(report).After(reportTime(latest))) {
+			latest = report
+		}
+	}
+	if latest != nil && time.Since(reportTime(latest)) < routeReportMaxAge {
+		return latest, nil
+	}
+
+	report, _, err := tgwService.CreateTransitGatewayRouteReport(&tgw.CreateTransitGatewayRouteReportOptions{TransitGatewayID: &tgwID})
+	if err != nil {
+		return nil, fmt.Errorf("[startRouteReport] error generating route report for %s: %w", tgwID, err)
+	}
+	return report, nil
+}
+
+func reportTime(report *tgw.RouteReport) time.Time {
+	if report.UpdatedAt != nil {
+		return time.Time(*report.UpdatedAt)
+	}
+	return time.Time(*report.CreatedAt)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const listPageSize = 500
+
+// Network policies of CNIs, collected if their CRDs are installed in the cluster
+var (
+	calicoPolicyResources = []schema.GroupVersionResource{
+		{Group: "crd.projectcalico.org", Version: "v1", Resource: "networkpolicies"},
+		{Group: "crd.projectcalico.org", Version: "v1", Resource: "globalnetworkpolicies"},
+	}
+	ciliumPolicyResources = []schema.GroupVersionResource{
+		{Group: "cilium.io", Version: "v2", Resource: "ciliumnetworkpolicies"},
+		{Group: "cilium.io", Version: "v2", Resource: "ciliumclusterwidenetworkpolicies"},
+	}
+)
+
+// listAll is a generic function for listing all objects of a type, page by page.
+// L is the type of a list (page), T is the type of the objects in the list
+func listAll[L metav1.ListInterface, T any](
+	listFunc func(context.Context, metav1.ListOptions) (L, error),
+	getItems func(L) []T,
+) ([]*T, error) {
+	res := []*T{}
+	opts := metav1.ListOptions{Limit: listPageSize}
+	for {
+		page, err := listFunc(context.TODO(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("[listAll] error listing objects: %w", err)
+		}
+		items := getItems(page)
+		for i := range items {
+			// managed fields only record which manager set each field, and are not part of the configuration
+			if obj, ok := any(&items[i]).(metav1.Object); ok {
+				obj.SetManagedFields(nil)
+			}
+			res = append(res, &items[i])
+		}
+		opts.Continue = page.GetContinue()
+		if opts.Continue == "" {
+			return res, nil
+		}
+	}
+}
+
+// collectClusterResources collects the built-in resources of the cluster, from all namespaces
+func (resources *ResourcesContainer) collectClusterResources(clientset kubernetes.Interface) error {
+	var err error
+	resources.NamespacesList, err = listAll(clientset.CoreV1().Namespaces().List,
+		func(list *corev1.NamespaceList) []corev1.Namespace { return list.Items })
+	if err != nil {
+		return fmt.Errorf("[collectClusterResources] error getting namespaces: %w", err)
+	}
+
+	pods, err := listAll(clientset.CoreV1().Pods(metav1.NamespaceAll).List,
+		func(list *corev1.PodList) []corev1.Pod { return list.Items })
+	if err != nil {
+		return fmt.Errorf("[collectClusterResources] error getting pods: %w", err)
+	}
+	resources.PodsList = make([]*corev1.Pod, len(pods))
+	for i := range pods {
+		resources.PodsList[i] = reducedPod(pods[i])
+	}
+
+	resources.ServicesList, err = listAll(clientset.CoreV1().Services(metav1.NamespaceAll).List,
+		func(list *corev1.ServiceList) []corev1.Service { return list.Items })
+	if err != nil {
+		return fmt.Errorf("[collectClusterResources] error getting services: %w", err)
+	}
+
+	resources.EndpointsList, err = listAll(clientset.CoreV1().Endpoints(metav1.NamespaceAll).List,
+		func(list *corev1.EndpointsList) []corev1.Endpoints { return list.Items })
+	if err != nil {
+		return fmt.Errorf("[collectClusterResources] error getting endpoints: %w", err)
+	}
+
+	resources.EndpointSlicesList, err = listAll(clientset.DiscoveryV1().EndpointSlices(metav1.NamespaceAll).List,
+		func(list *discoveryv1.EndpointSliceList) []discoveryv1.EndpointSlice { return list.Items })
+	if err != nil {
+		return fmt.Errorf("[collectClusterResources] error getting endpoint slices: %w", err)
+	}
+
+	resources.IngressesList, err = listAll(clientset.NetworkingV1().Ingresses(metav1.NamespaceAll).List,
+		func(list *networkingv1.IngressList) []networkingv1.Ingress { return list.Items })
+	if err != nil {
+		return fmt.Errorf("[collectClusterResources] error getting ingresses: %w", err)
+	}
+
+	resources.NetworkPoliciesList, err = listAll(clientset.NetworkingV1().NetworkPolicies(metav1.NamespaceAll).List,
+		func(list *networkingv1.NetworkPolicyList) []networkingv1.NetworkPolicy { return list.Items })
+	if err != nil {
+		return fmt.Errorf("[collectClusterResources] error getting network policies: %w", err)
+	}
+
+	return nil
+}
+
+// reducedPod returns the parts of a pod that determine its connectivity: its identity, labels and owners, its node,
+// its IPs and the ports of its containers. Other fields, such as container environment variables (which may hold
+// secrets) and annotations (which may hold the full last-applied manifest), are dropped
+func reducedPod(pod *corev1.Pod) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            pod.Name,
+			Namespace:       pod.Namespace,
+			UID:             pod.UID,
+			Labels:          pod.Labels,
+			OwnerReferences: pod.OwnerReferences,
+		},
+		Spec: corev1.PodSpec{
+			NodeName:       pod.Spec.NodeName,
+			HostNetwork:    pod.Spec.HostNetwork,
+			InitContainers: reducedContainers(pod.Spec.InitContainers),
+			Containers:     reducedContainers(pod.Spec.Containers),
+		},
+		Status: corev1.PodStatus{
+			Phase:   pod.Status.Phase,
+			HostIP:  pod.Status.HostIP,
+			HostIPs: pod.Status.HostIPs,
+			PodIP:   pod.Status.PodIP,
+			PodIPs:  pod.Status.PodIPs,
+		},
+	}
+}
+
+// reducedContainers returns the names and ports of the given containers
+func reducedContainers(containers []corev1.Container) []corev1.Container {
+	if containers == nil {
+		return nil
+	}
+	res := make([]corev1.Container, len(containers))
+	for i := range containers {
+		res[i] = corev1.Container{Name: containers[i].Name, Ports: containers[i].Ports}
+	}
+	return res
+}
+
+// collectPolicyCRDs collects Calico and Cilium policies, if their CRDs are installed in the cluster
+func (resources *ResourcesContainer) collectPolicyCRDs(clientset kubernetes.Interface, dynamicClient dynamic.Interface) error {
+	var err error
+	resources.CalicoPoliciesList, err = listCustomResources(clientset, dynamicClient, calicoPolicyResources)
+	if err != nil {
+		return err
+	}
+	resources.CiliumPoliciesList, err = listCustomResources(clientset, dynamicClient, ciliumPolicyResources)
+	return err
+}
+
+// listCustomResources lists the objects of the given custom resources, skipping resources not served by the cluster
+func listCustomResources(clientset kubernetes.Interface, dynamicClient dynamic.Interface,
+	gvrs []schema.GroupVersionResource) ([]*unstructured.Unstructured, error) {
+	res := []*unstructured.Unstructured{}
+	for _, gvr := range gvrs {
+		served, err := isResourceServed(clientset, gvr)
+		if err != nil {
+			return nil, err
+		}
+		if !served {
+			continue
+		}
+		objects, err := listAll(dynamicClient.Resource(gvr).Namespace(metav1.NamespaceAll).List,
+			func(list *unstructured.UnstructuredList) []unstructured.Unstructured { return list.Items })
+		if err != nil {
+			return nil, fmt.Errorf("[listCustomResources] error getting %s: %w", gvr.String(), err)
+		}
+		res = append(res, objects...)
+	}
+	return res, nil
+}
+
+func isResourceServed(clientset kubernetes.Interface, gvr schema.GroupVersionResource) (bool, error) {
+	resourceList, err := clientset.Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if apierrors.IsNotFound(err) {
+		log.Printf("Skipping %s, which is not served by the cluster\n", gvr.String())
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("[isResourceServed] error discovering %s: %w", gvr.GroupVersion().String(), err)
+	}
+	return slices.ContainsFunc(resourceList.APIResources, func(r metav1.APIResource) bool { return r.Name == gvr.Resource }), nil
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package k8s
+
+import (
+	"github.com/spf13/pflag"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/common"
+)
+
+// RegisterProvider registers the Kubernetes provider, together with its collect flags
+func RegisterProvider() error {
+	options := &CollectOptions{}
+	flags := pflag.NewFlagSet(string(common.K8s), pflag.ContinueOnError)
+	flags.StringVar(&options.Kubeconfig, "kubeconfig", "",
+		"path to the kubeconfig file of the cluster (default: $KUBECONFIG or ~/.kube/config)")
+	flags.StringVar(&options.Context, "k8s-context", "", "kubeconfig context to use (default: the current context)")
+
+	return common.RegisterProvider(&common.ProviderInfo{
+		Name: common.K8s,
+		NewResourcesContainer: func(_ []string, _ string) common.ResourcesContainerInf {
+			return NewResourcesContainer(options)
+		},
+		AllRegions: func() []string { return []string{} },
+		Flags:      flags,
+	})
+}
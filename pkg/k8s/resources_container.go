@@ -0,0 +1,130 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/common"
+	"github.com/np-guard/cloud-resource-collector/pkg/version"
+)
+
+// ResourcesContainer holds the results of collecting the configurations of all resources in a cluster.
+// This includes: namespaces, pods, services, endpoints, endpoint slices, ingresses and network policies,
+// including Calico and Cilium policies, if these are installed in the cluster
+type ResourcesContainer struct {
+	common.ResourceModelMetadata
+	CalicoPoliciesList  []*unstructured.Unstructured  `json:"calico_policies"`
+	CiliumPoliciesList  []*unstructured.Unstructured  `json:"cilium_policies"`
+	EndpointSlicesList  []*discoveryv1.EndpointSlice  `json:"endpoint_slices"`
+	EndpointsList       []*corev1.Endpoints           `json:"endpoints"`
+	IngressesList       []*networkingv1.Ingress       `json:"ingresses"`
+	NamespacesList      []*corev1.Namespace           `json:"namespaces"`
+	NetworkPoliciesList []*networkingv1.NetworkPolicy `json:"network_policies"`
+	PodsList            []*corev1.Pod                 `json:"pods"`
+	ServicesList        []*corev1.Service             `json:"services"`
+	options             CollectOptions
+}
+
+// CollectOptions holds the options for connecting to the cluster
+type CollectOptions struct {
+	// Kubeconfig is the path to the kubeconfig file. If empty, the path in $KUBECONFIG or ~/.kube/config is used
+	Kubeconfig string
+	// Context is the kubeconfig context to use. If empty, the current context is used
+	Context string
+	// Clientset and DynamicClient override the clients created from the kubeconfig, e.g., with fake clients in tests
+	Clientset     kubernetes.Interface
+	DynamicClient dynamic.Interface
+}
+
+// NewResourcesContainer creates an empty resources container
+func NewResourcesContainer(options *CollectOptions) *ResourcesContainer {
+	if options == nil {
+		options = &CollectOptions{}
+	}
+	return &ResourcesContainer{
+		CalicoPoliciesList:    []*unstructured.Unstructured{},
+		CiliumPoliciesList:    []*unstructured.Unstructured{},
+		EndpointSlicesList:    []*discoveryv1.EndpointSlice{},
+		EndpointsList:         []*corev1.Endpoints{},
+		IngressesList:         []*networkingv1.Ingress{},
+		NamespacesList:        []*corev1.Namespace{},
+		NetworkPoliciesList:   []*networkingv1.NetworkPolicy{},
+		PodsList:              []*corev1.Pod{},
+		ServicesList:          []*corev1.Service{},
+		ResourceModelMetadata: common.ResourceModelMetadata{Version: version.VersionCore, Provider: string(common.K8s)},
+		options:               *options,
+	}
+}
+
+// PrintStats outputs the number of items of each type
+func (resources *ResourcesContainer) PrintStats() {
+	fmt.Printf("Found %d Calico policies\n", len(resources.CalicoPoliciesList))
+	fmt.Printf("Found %d Cilium policies\n", len(resources.CiliumPoliciesList))
+	fmt.Printf("Found %d endpoint slices\n", len(resources.EndpointSlicesList))
+	fmt.Printf("Found %d endpoints\n", len(resources.EndpointsList))
+	fmt.Printf("Found %d ingresses\n", len(resources.IngressesList))
+	fmt.Printf("Found %d namespaces\n", len(resources.NamespacesList))
+	fmt.Printf("Found %d network policies\n", len(resources.NetworkPoliciesList))
+	fmt.Printf("Found %d pods\n", len(resources.PodsList))
+	fmt.Printf("Found %d services\n", len(resources.ServicesList))
+}
+
+// ToJSONString converts a ResourcesContainer into a json-formatted-string
+func (resources *ResourcesContainer) ToJSONString() (string, error) {
+	toPrint, err := json.MarshalIndent(resources, "", "    ")
+	return string(toPrint), err
+}
+
+// AllRegions returns no regions, as a cluster is not divided into regions
+func (resources *ResourcesContainer) AllRegions() []string {
+	return []string{}
+}
+
+func (resources *ResourcesContainer) GetResources() common.ResourcesModel {
+	return resources
+}
+
+func (resources *ResourcesContainer) Fabricate(opts *common.FabricateOptions) {
+}
+
+// CollectResourcesFromAPI uses the Kubernetes API to collect the configuration of resources in the cluster
+func (resources *ResourcesContainer) CollectResourcesFromAPI() error {
+	clientset, dynamicClient := resources.options.Clientset, resources.options.DynamicClient
+	if clientset == nil || dynamicClient == nil {
+		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+		loadingRules.ExplicitPath = resources.options.Kubeconfig
+		overrides := &clientcmd.ConfigOverrides{CurrentContext: resources.options.Context}
+		config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+		if err != nil {
+			return fmt.Errorf("CollectResourcesFromAPI error loading kubeconfig: %w", err)
+		}
+		clientset, err = kubernetes.NewForConfig(config)
+		if err != nil {
+			return fmt.Errorf("CollectResourcesFromAPI error creating clientset: %w", err)
+		}
+		dynamicClient, err = dynamic.NewForConfig(config)
+		if err != nil {
+			return fmt.Errorf("CollectResourcesFromAPI error creating dynamic client: %w", err)
+		}
+	}
+
+	err := resources.collectClusterResources(clientset)
+	if err != nil {
+		return err
+	}
+	return resources.collectPolicyCRDs(clientset, dynamicClient)
+}
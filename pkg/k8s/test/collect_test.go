@@ -0,0 +1,150 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package test
+
+import (
+	"os"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/np-guard/cloud-resource-collector/pkg/k8s"
+)
+
+const (
+	expectedOutputFile = "data/k8s_example.json"
+	namespace          = "shop"
+)
+
+var calicoPolicies = schema.GroupVersionResource{Group: "crd.projectcalico.org", Version: "v1", Resource: "networkpolicies"}
+
+func newFakeClusterObjects() []runtime.Object {
+	appLabels := map[string]string{"app": "frontend"}
+	tcp := corev1.ProtocolTCP
+	port := int32(8080)
+	portName := "http"
+	ready := true
+	pathType := networkingv1.PathTypePrefix
+	return []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: map[string]string{"team": "shop"}}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend-1", Namespace: namespace, Labels: appLabels,
+				Annotations:     map[string]string{"kubectl.kubernetes.io/last-applied-configuration": `{"kind":"Pod"}`},
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "frontend-7d4b9", UID: "rs-uid"}}},
+			Spec: corev1.PodSpec{
+				NodeName: "worker-1",
+				InitContainers: []corev1.Container{{Name: "init", Image: "busybox",
+					Env: []corev1.EnvVar{{Name: "DB_PASSWORD", Value: "secret"}}}},
+				Containers: []corev1.Container{{Name: "web", Image: "nginx", Ports: []corev1.ContainerPort{{ContainerPort: port}},
+					Env: []corev1.EnvVar{{Name: "API_TOKEN", Value: "secret"}},
+					EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "creds"}}}}}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, HostIP: "10.240.0.4", PodIP: "172.30.1.5",
+				PodIPs: []corev1.PodIP{{IP: "172.30.1.5"}}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: namespace},
+			Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, ClusterIP: "172.21.10.10", Selector: appLabels,
+				Ports: []corev1.ServicePort{{Name: portName, Protocol: tcp, Port: 80, TargetPort: intstr.FromInt32(port)}}},
+		},
+		&corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: namespace},
+			Subsets: []corev1.EndpointSubset{{
+				Addresses: []corev1.EndpointAddress{{IP: "172.30.1.5", TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "frontend-1"}}},
+				Ports:     []corev1.EndpointPort{{Name: portName, Port: port, Protocol: tcp}},
+			}},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend-abcde", Namespace: namespace,
+				Labels: map[string]string{"kubernetes.io/service-name": "frontend"}},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints: []discoveryv1.Endpoint{{Addresses: []string{"172.30.1.5"}, Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+				TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "frontend-1"}}},
+			Ports: []discoveryv1.EndpointPort{{Name: &portName, Port: &port, Protocol: &tcp}},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: namespace},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+				Host: "shop.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{Paths: []networkingv1.HTTPIngressPath{{
+					Path: "/", PathType: &pathType,
+					Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+						Name: "frontend", Port: networkingv1.ServiceBackendPort{Number: 80}}},
+				}}}},
+			}}},
+		},
+		&networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend-ingress", Namespace: namespace},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: appLabels},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{
+					From:  []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "shop"}}}},
+					Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &intstr.IntOrString{Type: intstr.Int, IntVal: port}}},
+				}},
+			},
+		},
+	}
+}
+
+func newFakeCalicoPolicy() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "crd.projectcalico.org/v1",
+		"kind":       "NetworkPolicy",
+		"metadata":   map[string]any{"name": "default.deny-egress", "namespace": namespace},
+		"spec": map[string]any{
+			"selector": "app == 'frontend'",
+			"types":    []any{"Egress"},
+			"egress":   []any{map[string]any{"action": "Deny", "destination": map[string]any{"nets": []any{"0.0.0.0/0"}}}},
+		},
+	}}
+}
+
+func TestCollectFromFakeCluster(t *testing.T) {
+	clientset := fake.NewClientset(newFakeClusterObjects()...)
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+		GroupVersion: calicoPolicies.GroupVersion().String(),
+		APIResources: []metav1.APIResource{{Name: calicoPolicies.Resource, Namespaced: true, Kind: "NetworkPolicy"}},
+	}}
+	dynamicClient := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{calicoPolicies: "NetworkPolicyList"}, newFakeCalicoPolicy())
+
+	resources := k8s.NewResourcesContainer(&k8s.CollectOptions{Clientset: clientset, DynamicClient: dynamicClient})
+	err := resources.CollectResourcesFromAPI()
+	if err != nil {
+		t.Fatalf("CollectResourcesFromAPI failed: %v", err)
+	}
+	toPrint, err := resources.ToJSONString()
+	if err != nil {
+		t.Fatalf("ToJSONString failed: %v", err)
+	}
+
+	expected, err := os.ReadFile(expectedOutputFile)
+	if err != nil {
+		t.Fatalf("couldn't read file: %s", expectedOutputFile)
+	}
+	if string(expected) != toPrint {
+		t.Errorf("Collected resources differ from %s", expectedOutputFile)
+
+		// Used for debugging test failures:
+		err = os.WriteFile("collect_output.json", []byte(toPrint), 0o600)
+		if err != nil {
+			t.Errorf("failed with error %v", err)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tfexport generates Terraform configurations (HCL) recreating collected resources.
+// Resources reference each other by Terraform references (e.g., ibm_is_vpc.main.id), so that the generated
+// configuration can be applied in another account
+package tfexport
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// ProvidersFile is the file holding the required providers and their configurations
+	ProvidersFile = "providers.tf"
+	tfFileSuffix  = ".tf"
+)
+
+// Config is a Terraform configuration made of several files, to which resources are added
+type Config struct {
+	files map[string]*hclwrite.File
+	// the labels used for each resource type, and the address ("<type>.<label>") of the resource exported for each ID
+	labels    map[string]map[string]bool
+	addresses map[string]string
+	// the name of the Terraform provider, and whether resources select a provider configuration by region
+	provider    string
+	multiRegion bool
+}
+
+// NewConfig creates an empty configuration
+func NewConfig() *Config {
+	return &Config{files: map[string]*hclwrite.File{}, labels: map[string]map[string]bool{}, addresses: map[string]string{}}
+}
+
+// AddProvider adds the required provider, with a provider configuration for each region, to the providers file.
+// With more than one region, each configuration has an alias, which the resources in the region select, and a
+// default configuration without an alias (in the first region) serves the resources with no region
+func (c *Config) AddProvider(name, source string, regions []string) {
+	c.provider = name
+	c.multiRegion = len(regions) > 1
+
+	body := c.file(ProvidersFile)
+	requiredProviders := body.AppendNewBlock("terraform", nil).Body().AppendNewBlock("required_providers", nil).Body()
+	requiredProviders.SetAttributeValue(name, cty.ObjectVal(map[string]cty.Value{"source": cty.StringVal(source)}))
+	if c.multiRegion {
+		body.AppendNewline()
+		body.AppendNewBlock("provider", []string{name}).Body().SetAttributeValue("region", cty.StringVal(regions[0]))
+	}
+	for _, region := range regions {
+		body.AppendNewline()
+		providerBody := body.AppendNewBlock("provider", []string{name}).Body()
+		if c.multiRegion {
+			providerBody.SetAttributeValue("alias", cty.StringVal(regionAlias(region)))
+		}
+		providerBody.SetAttributeValue("region", cty.StringVal(region))
+	}
+}
+
+// AddResource appends a resource block to a file, and returns its body. The label of the resource is derived from
+// name, and is unique among the resources of the same type. If id is not empty, later references to id refer to this
+// resource. If region is not empty, the resource selects the provider configuration of the region (otherwise, it uses
+// the default configuration)
+func (c *Config) AddResource(fileName, resourceType, name, id, region string) *hclwrite.Body {
+	if c.labels[resourceType] == nil {
+		c.labels[resourceType] = map[string]bool{}
+	}
+	resourceLabel := label(name)
+	for i := 2; c.labels[resourceType][resourceLabel]; i++ {
+		resourceLabel = label(name) + "_" + strconv.Itoa(i)
+	}
+	c.labels[resourceType][resourceLabel] = true
+	if id != "" {
+		c.addresses[id] = resourceType + "." + resourceLabel
+	}
+
+	body := c.file(fileName)
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	resourceBody := body.AppendNewBlock("resource", []string{resourceType, resourceLabel}).Body()
+	if c.multiRegion && region != "" {
+		resourceBody.SetAttributeTraversal("provider", hcl.Traversal{hcl.TraverseRoot{Name: c.provider},
+			hcl.TraverseAttr{Name: regionAlias(region)}})
+	}
+	return resourceBody
+}
+
+// Exported returns true if a resource was exported for the given ID
+func (c *Config) Exported(id string) bool {
+	_, ok := c.addresses[id]
+	return ok
+}
+
+// Ref returns a reference to an attribute of the resource exported for the given ID (to the resource itself if attr is
+// empty). If no resource was exported for the ID, the ID itself is returned, as a string
+func (c *Config) Ref(id, attr string) hclwrite.Tokens {
+	address, ok := c.addresses[id]
+	if !ok {
+		return hclwrite.TokensForValue(cty.StringVal(id))
+	}
+	resourceType, resourceLabel, _ := strings.Cut(address, ".")
+	traversal := hcl.Traversal{hcl.TraverseRoot{Name: resourceType}, hcl.TraverseAttr{Name: resourceLabel}}
+	if attr != "" {
+		traversal = append(traversal, hcl.TraverseAttr{Name: attr})
+	}
+	return hclwrite.TokensForTraversal(traversal)
+}
+
+// Refs returns a list of references, as returned by Ref, to the resources exported for the given IDs
+func (c *Config) Refs(ids []string, attr string) hclwrite.Tokens {
+	elems := make([]hclwrite.Tokens, len(ids))
+	for i, id := range ids {
+		elems[i] = c.Ref(id, attr)
+	}
+	return hclwrite.TokensForTuple(elems)
+}
+
+// Files returns the formatted content of all files, by file name
+func (c *Config) Files() map[string][]byte {
+	res := map[string][]byte{}
+	for name, file := range c.files {
+		res[name] = hclwrite.Format(file.Bytes())
+	}
+	return res
+}
+
+// FileNames returns the names of all files, sorted
+func (c *Config) FileNames() []string {
+	res := make([]string, 0, len(c.files))
+	for name := range c.files {
+		res = append(res, name)
+	}
+	slices.Sort(res)
+	return res
+}
+
+func (c *Config) file(name string) *hclwrite.Body {
+	if !strings.HasSuffix(name, tfFileSuffix) {
+		name += tfFileSuffix
+	}
+	if c.files[name] == nil {
+		c.files[name] = hclwrite.NewEmptyFile()
+	}
+	return c.files[name].Body()
+}
+
+// SetString sets a string attribute, unless the value is nil or empty
+func SetString(body *hclwrite.Body, name string, val *string) {
+	if val != nil && *val != "" {
+		body.SetAttributeValue(name, cty.StringVal(*val))
+	}
+}
+
+// SetInt sets a number attribute, unless the value is nil
+func SetInt[T int32 | int64](body *hclwrite.Body, name string, val *T) {
+	if val != nil {
+		body.SetAttributeValue(name, cty.NumberIntVal(int64(*val)))
+	}
+}
+
+// SetStrings sets a list of strings attribute, unless the list is empty
+func SetStrings(body *hclwrite.Body, name string, vals []string) {
+	if len(vals) == 0 {
+		return
+	}
+	list := make([]cty.Value, len(vals))
+	for i, val := range vals {
+		list[i] = cty.StringVal(val)
+	}
+	body.SetAttributeValue(name, cty.ListVal(list))
+}
+
+// SetStringMap sets a map of strings attribute (e.g., tags), unless the map is empty
+func SetStringMap(body *hclwrite.Body, name string, vals map[string]string) {
+	if len(vals) == 0 {
+		return
+	}
+	m := map[string]cty.Value{}
+	for key, val := range vals {
+		m[key] = cty.StringVal(val)
+	}
+	body.SetAttributeValue(name, cty.MapVal(m))
+}
+
+// label converts a resource name into a valid Terraform label, made of letters, digits, underscores and dashes
+func label(name string) string {
+	res := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' {
+			return r
+		}
+		return '_'
+	}, name)
+	if res == "" || res[0] >= '0' && res[0] <= '9' || res[0] == '-' {
+		res = "r_" + res
+	}
+	return res
+}
+
+func regionAlias(region string) string {
+	return strings.ReplaceAll(region, "-", "_")
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tfstate
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// Attributes are the attributes of a single resource (or of a nested block), as recorded by Terraform.
+// Attributes that are missing, null or of an unexpected type are treated as unset by all accessors
+type Attributes map[string]any
+
+// String returns the value of a string attribute, or "" if it is unset
+func (attrs Attributes) String(key string) string {
+	val, _ := attrs[key].(string)
+	return val
+}
+
+// StringPtr returns the value of a string attribute, or nil if it is unset or empty
+func (attrs Attributes) StringPtr(key string) *string {
+	val := attrs.String(key)
+	if val == "" {
+		return nil
+	}
+	return &val
+}
+
+// Int64Ptr returns the value of a number attribute, or nil if it is unset
+func (attrs Attributes) Int64Ptr(key string) *int64 {
+	num, ok := attrs[key].(json.Number)
+	if !ok {
+		return nil
+	}
+	val, err := num.Int64()
+	if err != nil {
+		return nil
+	}
+	return &val
+}
+
+// Int32Ptr returns the value of a number attribute, or nil if it is unset or out of range
+func (attrs Attributes) Int32Ptr(key string) *int32 {
+	val := attrs.Int64Ptr(key)
+	if val == nil || *val < math.MinInt32 || *val > math.MaxInt32 {
+		return nil
+	}
+	res := int32(*val)
+	return &res
+}
+
+// BoolPtr returns the value of a bool attribute, or nil if it is unset
+func (attrs Attributes) BoolPtr(key string) *bool {
+	val, ok := attrs[key].(bool)
+	if !ok {
+		return nil
+	}
+	return &val
+}
+
+// Strings returns the values of a list (or set) of strings, or an empty slice if it is unset
+func (attrs Attributes) Strings(key string) []string {
+	list, _ := attrs[key].([]any)
+	res := []string{}
+	for _, item := range list {
+		if val, ok := item.(string); ok {
+			res = append(res, val)
+		}
+	}
+	return res
+}
+
+// StringMap returns the values of a map of strings (e.g., AWS tags), or an empty map if it is unset
+func (attrs Attributes) StringMap(key string) map[string]string {
+	m, _ := attrs[key].(map[string]any)
+	res := map[string]string{}
+	for k, item := range m {
+		if val, ok := item.(string); ok {
+			res[k] = val
+		}
+	}
+	return res
+}
+
+// Blocks returns the nested blocks of a block attribute (a list or a set of objects).
+// A single object (e.g., a map attribute) is returned as one block
+func (attrs Attributes) Blocks(key string) []Attributes {
+	res := []Attributes{}
+	switch val := attrs[key].(type) {
+	case []any:
+		for _, item := range val {
+			if block, ok := item.(map[string]any); ok {
+				res = append(res, block)
+			}
+		}
+	case map[string]any:
+		res = append(res, val)
+	}
+	return res
+}
+
+// Block returns the first nested block of a block attribute, or nil if there is none
+func (attrs Attributes) Block(key string) Attributes {
+	blocks := attrs.Blocks(key)
+	if len(blocks) == 0 {
+		return nil
+	}
+	return blocks[0]
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2023- IBM Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tfstate reads the resources recorded by Terraform, so that their configuration can be translated into the
+// resource models of the collector without calling any cloud API (and without cloud credentials).
+// Both raw state files (terraform.tfstate) and the JSON output of "terraform show -json" (for a state or a plan) are supported
+package tfstate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+const (
+	stateFormatVersion = 4
+	managedMode        = "managed"
+)
+
+// State holds the attributes of all managed resources in a Terraform state (or plan), by resource type
+type State struct {
+	resources map[string][]Attributes
+}
+
+// The format of raw state files
+type rawState struct {
+	Version   int `json:"version"`
+	Resources []struct {
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Instances []struct {
+			Attributes Attributes `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// The format of "terraform show -json" output
+type showOutput struct {
+	FormatVersion string        `json:"format_version"`
+	Values        *valuesOutput `json:"values"`
+	PlannedValues *valuesOutput `json:"planned_values"`
+}
+
+type valuesOutput struct {
+	RootModule moduleOutput `json:"root_module"`
+}
+
+type moduleOutput struct {
+	Resources []struct {
+		Mode   string     `json:"mode"`
+		Type   string     `json:"type"`
+		Values Attributes `json:"values"`
+	} `json:"resources"`
+	ChildModules []moduleOutput `json:"child_modules"`
+}
+
+// Load reads a Terraform state from a file
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("[Load] error reading Terraform state: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse reads a Terraform state from the content of a raw state file or of "terraform show -json" output.
+// For a plan, the planned values of resources are used
+func Parse(data []byte) (*State, error) {
+	state := &State{resources: map[string][]Attributes{}}
+
+	var show showOutput
+	if err := unmarshal(data, &show); err != nil {
+		return nil, fmt.Errorf("[Parse] error parsing Terraform state: %w", err)
+	}
+	if show.FormatVersion != "" {
+		switch {
+		case show.PlannedValues != nil:
+			state.addModule(&show.PlannedValues.RootModule)
+		case show.Values != nil:
+			state.addModule(&show.Values.RootModule)
+		}
+		return state, nil
+	}
+
+	var raw rawState
+	if err := unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("[Parse] error parsing Terraform state: %w", err)
+	}
+	if raw.Version != stateFormatVersion {
+		return nil, fmt.Errorf("[Parse] unsupported Terraform state version %d (expected %d)", raw.Version, stateFormatVersion)
+	}
+	for _, resource := range raw.Resources {
+		if resource.Mode != managedMode {
+			continue
+		}
+		for _, instance := range resource.Instances {
+			state.resources[resource.Type] = append(state.resources[resource.Type], instance.Attributes)
+		}
+	}
+	return state, nil
+}
+
+// Resources returns the attributes of all managed resources of the given type (e.g., "ibm_is_vpc"), in state order
+func (state *State) Resources(resourceType string) []Attributes {
+	return state.resources[resourceType]
+}
+
+func (state *State) addModule(module *moduleOutput) {
+	for _, resource := range module.Resources {
+		if resource.Mode == managedMode {
+			state.resources[resource.Type] = append(state.resources[resource.Type], resource.Values)
+		}
+	}
+	for i := range module.ChildModules {
+		state.addModule(&module.ChildModules[i])
+	}
+}
+
+// unmarshal keeps numbers as json.Number, so that large integers are not rounded
+func unmarshal(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}